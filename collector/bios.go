@@ -0,0 +1,116 @@
+package collector
+
+import (
+	"fmt"
+	"github.com/pallasscat/redfish_exporter/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"sort"
+)
+
+type BIOSCollector struct {
+	client   *gofish.APIClient
+	baseline config.BIOSBaseline
+	bios     *biosInventory
+}
+
+// biosResource holds the BIOS settings link gofish does not expose.
+type biosResource struct {
+	Settings common.Settings `json:"@Redfish.Settings"`
+}
+
+// biosAttributes is the pending BIOS settings resource.
+type biosAttributes struct {
+	Attributes redfish.BiosAttributes
+}
+
+func (c *BIOSCollector) Collect(ch chan<- prometheus.Metric) error {
+	systems, err := c.client.Service.Systems()
+	if err != nil {
+		return fmt.Errorf("error collecting /Systems: %s", err)
+	}
+
+	for _, system := range systems {
+		bios, err := c.bios.get(system)
+		if err != nil {
+			return fmt.Errorf("error collecting /Systems/%s/Bios: %s", system.ID, err)
+		} else if bios == nil {
+			continue
+		}
+
+		var resource biosResource
+		if err := getResource(c.client, bios.ODataID, &resource); err != nil {
+			return fmt.Errorf("error collecting /Systems/%s/Bios: %s", system.ID, err)
+		}
+
+		var pending biosAttributes
+		if settings := string(resource.Settings.SettingsObject); settings != "" && settings != bios.ODataID {
+			if err := getResource(c.client, settings, &pending); err != nil {
+				return fmt.Errorf("error collecting /Systems/%s/Bios/Settings: %s", system.ID, err)
+			}
+		}
+
+		c.processBIOS(ch, bios, pending, c.baseline[system.Model], system.ID)
+	}
+
+	return nil
+}
+
+func (c *BIOSCollector) processBIOS(ch chan<- prometheus.Metric, bios *redfish.Bios, pending biosAttributes, baseline map[string]string, systemID string) {
+	constLabels := prometheus.Labels{"system_id": systemID}
+
+	attributeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bios", "attribute"),
+		"Numeric BIOS attribute value; booleans as 0: False, 1: True",
+		[]string{"attribute"}, constLabels,
+	)
+	attributeInfoDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bios", "attribute_info"),
+		"BIOS attribute value",
+		[]string{"attribute", "value"}, constLabels,
+	)
+	compliantDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bios", "attribute_compliant"),
+		"BIOS attribute matches the baseline for the system model; 0: No, 1: Yes",
+		[]string{"attribute"}, constLabels,
+	)
+	pendingDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bios", "pending_attributes"),
+		"BIOS attributes with changes pending a reboot to apply",
+		nil, constLabels,
+	)
+
+	// only attributes in the baseline are exported, the full set runs into the hundreds
+	attributes := make([]string, 0, len(baseline))
+	for attribute := range baseline {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	for _, attribute := range attributes {
+		value, ok := bios.Attributes[attribute]
+		if ok {
+			switch v := value.(type) {
+			case float64:
+				ch <- prometheus.MustNewConstMetric(attributeDesc, prometheus.GaugeValue, v, attribute)
+			case bool:
+				ch <- prometheus.MustNewConstMetric(attributeDesc, prometheus.GaugeValue, btof(v), attribute)
+			case string:
+				ch <- prometheus.MustNewConstMetric(attributeInfoDesc, prometheus.GaugeValue, 1, attribute, v)
+			}
+		}
+
+		compliant := ok && bios.Attributes.String(attribute) == baseline[attribute]
+		ch <- prometheus.MustNewConstMetric(compliantDesc, prometheus.GaugeValue, btof(compliant), attribute)
+	}
+
+	var changes float64
+	for attribute := range pending.Attributes {
+		if pending.Attributes.String(attribute) != bios.Attributes.String(attribute) {
+			changes++
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(pendingDesc, prometheus.GaugeValue, changes)
+}
@@ -0,0 +1,193 @@
+package collector
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+)
+
+type CertificateCollector struct {
+	client *gofish.APIClient
+}
+
+type certificateService struct {
+	CertificateLocations common.Link
+}
+
+type certificateLocations struct {
+	Links struct {
+		Certificates []common.Link
+	}
+}
+
+type certificateIdentifier struct {
+	CommonName string
+}
+
+type certificate struct {
+	ID              string `json:"Id"`
+	CertificateType string
+	Issuer          certificateIdentifier
+	Subject         certificateIdentifier
+	ValidNotAfter   string
+	ValidNotBefore  string
+}
+
+func (c *CertificateCollector) Collect(ch chan<- prometheus.Metric) error {
+	var root serviceRootLinks
+	if err := getResource(c.client, common.DefaultServiceRoot, &root); err != nil {
+		return fmt.Errorf("error collecting service root: %s", err)
+	}
+
+	if root.CertificateService == "" {
+		return nil
+	}
+
+	var service certificateService
+	if err := getResource(c.client, string(root.CertificateService), &service); err != nil {
+		return fmt.Errorf("error collecting /CertificateService: %s", err)
+	}
+
+	if service.CertificateLocations == "" {
+		return nil
+	}
+
+	var locations certificateLocations
+	if err := getResource(c.client, string(service.CertificateLocations), &locations); err != nil {
+		return fmt.Errorf("error collecting /CertificateService/CertificateLocations: %s", err)
+	}
+
+	for _, link := range locations.Links.Certificates {
+		var cert certificate
+		if err := getResource(c.client, string(link), &cert); err != nil {
+			return fmt.Errorf("error collecting %s: %s", link, err)
+		}
+
+		// certificates live in a collection under the resource using them,
+		// e.g. /Managers/1/NetworkProtocol/HTTPS/Certificates/1
+		c.processCertificate(ch, cert, path.Dir(path.Dir(string(link))))
+	}
+
+	return nil
+}
+
+func (c *CertificateCollector) processCertificate(ch chan<- prometheus.Metric, cert certificate, location string) {
+	notBefore, _ := parseTime(cert.ValidNotBefore)
+	notAfter, _ := parseTime(cert.ValidNotAfter)
+
+	processValidity(ch, location, cert.ID, cert.Subject.CommonName, cert.Issuer.CommonName, notBefore, notAfter)
+}
+
+// processValidity exports the validity period of a certificate, both for
+// certificates managed by the service and the one presented to the exporter.
+func processValidity(ch chan<- prometheus.Metric, location string, id string, subject string, issuer string, notBefore float64, notAfter float64) {
+	constLabels := prometheus.Labels{"location": location, "id": id, "subject": subject, "issuer": issuer}
+
+	notBeforeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "certificate", "not_before_seconds"),
+		"Certificate validity start, seconds since epoch",
+		nil, constLabels,
+	)
+	notAfterDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "certificate", "not_after_seconds"),
+		"Certificate expiry, seconds since epoch",
+		nil, constLabels,
+	)
+
+	if notBefore != 0 {
+		ch <- prometheus.MustNewConstMetric(notBeforeDesc, prometheus.GaugeValue, notBefore)
+	}
+	if notAfter != 0 {
+		ch <- prometheus.MustNewConstMetric(notAfterDesc, prometheus.GaugeValue, notAfter)
+	}
+}
+
+// peerCertificate records the certificate presented by the Redfish service
+// while connecting to it.
+type peerCertificate struct {
+	mu   sync.Mutex
+	cert *x509.Certificate
+}
+
+// httpClient returns an HTTP client set up like the gofish default one, which
+// records the peer certificate even when it fails verification.
+func (p *peerCertificate) httpClient(config gofish.ClientConfig) *http.Client {
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+
+	// the SNI server name is empty for IP targets, so verify against the endpoint
+	var host string
+	if u, err := url.Parse(config.Endpoint); err == nil {
+		host = u.Hostname()
+	}
+
+	handshakeTimeout := 10 * time.Second
+	if config.TLSHandshakeTimeout != 0 {
+		handshakeTimeout = time.Duration(config.TLSHandshakeTimeout) * time.Second
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 defaultTransport.Proxy,
+			DialContext:           defaultTransport.DialContext,
+			MaxIdleConns:          defaultTransport.MaxIdleConns,
+			IdleConnTimeout:       defaultTransport.IdleConnTimeout,
+			ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
+			TLSHandshakeTimeout:   handshakeTimeout,
+			TLSClientConfig: &tls.Config{
+				// verification is done in VerifyConnection, after the certificate is recorded
+				InsecureSkipVerify: true,
+				VerifyConnection: func(state tls.ConnectionState) error {
+					return p.verify(state, host, config.Insecure)
+				},
+			},
+		},
+	}
+}
+
+func (p *peerCertificate) verify(state tls.ConnectionState, host string, insecure bool) error {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	p.cert = state.PeerCertificates[0]
+	p.mu.Unlock()
+
+	if insecure {
+		return nil
+	} else if host == "" {
+		return fmt.Errorf("no host to verify the certificate against")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Intermediates: intermediates,
+	})
+
+	return err
+}
+
+func (p *peerCertificate) process(ch chan<- prometheus.Metric) {
+	p.mu.Lock()
+	cert := p.cert
+	p.mu.Unlock()
+
+	if cert == nil {
+		return
+	}
+
+	processValidity(ch, "peer", "", cert.Subject.CommonName, cert.Issuer.CommonName, float64(cert.NotBefore.Unix()), float64(cert.NotAfter.Unix()))
+}
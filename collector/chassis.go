@@ -4,55 +4,243 @@ import (
 	"fmt"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
+	"path"
 	"strconv"
+	"strings"
 )
 
 type ChassisCollector struct {
-	client *gofish.APIClient
+	client    *gofish.APIClient
+	state     targetState
+	inventory *chassisInventory
+}
+
+// chassisLinks holds the chassis links gofish does not expose.
+type chassisLinks struct {
+	EnvironmentMetrics common.Link
+	Power              common.Link
+	PowerSubsystem     common.Link
+	Sensors            common.Link
+	Thermal            common.Link
+	ThermalSubsystem   common.Link
+}
+
+type thermalSubsystem struct {
+	ID             string `json:"Id"`
+	Name           string
+	Fans           common.Link
+	ThermalMetrics common.Link
+	Status         common.Status
+}
+
+type subsystemFan struct {
+	ID              string `json:"Id"`
+	Name            string
+	PhysicalContext string
+	SpeedPercent    struct {
+		Reading  *float64
+		SpeedRPM *float64
+	}
+	Status common.Status
+}
+
+type thermalMetrics struct {
+	TemperatureReadingsCelsius []struct {
+		DataSourceURI   string `json:"DataSourceUri"`
+		DeviceName      string
+		PhysicalContext string
+		Reading         *float64
+	}
+}
+
+type powerSubsystem struct {
+	Batteries     common.Link
+	PowerSupplies common.Link
+}
+
+type subsystemPowerSupply struct {
+	ID                      string `json:"Id"`
+	Name                    string
+	InputNominalVoltageType string
+	Metrics                 common.Link
+	PowerCapacityWatts      float32
+	PowerSupplyType         redfish.PowerSupplyType
+	Status                  common.Status
+}
+
+type environmentMetrics struct {
+	ID           string `json:"Id"`
+	Name         string
+	EnergyJoules sensorExcerpt
+	EnergykWh    sensorExcerpt
+}
+
+type powerSupplyMetrics struct {
+	EnergykWh        sensorExcerpt
+	InputPowerWatts  sensorExcerpt
+	InputVoltage     sensorExcerpt
+	OutputPowerWatts sensorExcerpt
+}
+
+type battery struct {
+	ID                      string `json:"Id"`
+	Name                    string
+	CapacityActualWattHours *float64
+	ChargeState             string
+	StateOfHealthPercent    sensorExcerpt
+	Status                  common.Status
+}
+
+// networkAdapterLinks holds the network adapter links gofish does not expose.
+type networkAdapterLinks struct {
+	Metrics                common.Link
+	NetworkDeviceFunctions common.Link
+	Ports                  common.Link
+}
+
+type networkAdapterMetrics struct {
+	RXBytes           *float64
+	RXMulticastFrames *float64
+	RXUnicastFrames   *float64
+	TXBytes           *float64
+	TXMulticastFrames *float64
+	TXUnicastFrames   *float64
+}
+
+type port struct {
+	ID      string `json:"Id"`
+	Name    string
+	Metrics common.Link
+}
+
+type portMetrics struct {
+	Networking struct {
+		RXDiscards  *float64
+		RXFCSErrors *float64
+		RXFrames    *float64
+		TXDiscards  *float64
+		TXFrames    *float64
+	}
+	RXBytes  *float64
+	RXErrors *float64
+	TXBytes  *float64
+	TXErrors *float64
+}
+
+type networkDeviceFunction struct {
+	ID       string `json:"Id"`
+	Name     string
+	Ethernet struct {
+		MACAddress *string
+		MTUSize    *float64
+		VLAN       struct {
+			VLANEnable bool
+			VLANID     *float64 `json:"VLANId"`
+		}
+	}
+	ISCSIBoot struct {
+		InitiatorIPAddress string
+	} `json:"iSCSIBoot"`
+	Links struct {
+		// EthernetInterface is replaced by EthernetInterfaces in newer
+		// versions of the schema
+		EthernetInterface  common.Link
+		EthernetInterfaces common.Links
+	}
+	Metrics        common.Link
+	NetDevFuncType string
+	Status         common.Status
+}
+
+type networkDeviceFunctionMetrics struct {
+	RXBytes  *float64
+	RXFrames *float64
+	TXBytes  *float64
+	TXFrames *float64
 }
 
 func (c *ChassisCollector) Collect(ch chan<- prometheus.Metric) error {
-	chassiss, err := c.client.Service.Chassis()
+	entries, err := c.inventory.get()
 	if err != nil {
-		return fmt.Errorf("error collecting /Chassis: %s", err)
+		return err
 	}
 
-	for _, chassis := range chassiss {
-		c.processChassis(ch, chassis)
-
-		thermal, err := chassis.Thermal()
-		if err != nil {
-			return fmt.Errorf("error collecting /Chassis/%s/Thermal: %s", chassis.ID, err)
-		} else if thermal != nil {
-			c.processThermal(ch, thermal, chassis.ID)
+	for _, entry := range entries {
+		chassis, links := entry.chassis, entry.links
+		c.processChassis(ch, chassis, c.state.observeChassis(chassis.ID, chassis.PowerState))
 
-			for _, fan := range thermal.Fans {
-				c.processFan(ch, fan, chassis.ID)
+		var subsystem thermalSubsystem
+		if ok, err := entry.resource("ThermalSubsystem", &subsystem); err != nil {
+			return err
+		} else if ok {
+			if err := c.collectThermalSubsystem(ch, subsystem, entry.sensors, chassis.ID); err != nil {
+				return err
 			}
+		} else {
+			var thermal redfish.Thermal
+			var thresholds legacyResourceThresholds
+			if ok, err := entry.resource("Thermal", &thermal); err != nil {
+				return err
+			} else if ok {
+				if _, err := entry.resource("Thermal", &thresholds); err != nil {
+					return err
+				}
 
-			for _, t := range thermal.Temperatures {
-				c.processTemperature(ch, t, chassis.ID)
+				c.processThermal(ch, &thermal, chassis.ID)
+
+				for i, fan := range thermal.Fans {
+					c.processFan(ch, fan, thresholdsAt(thresholds.Fans, i).thresholds(), chassis.ID)
+				}
+
+				for i, t := range thermal.Temperatures {
+					c.processTemperature(ch, t, thresholdsAt(thresholds.Temperatures, i).thresholds(), chassis.ID)
+				}
 			}
 		}
 
-		power, err := chassis.Power()
-		if err != nil {
-			return fmt.Errorf("error collecting /Chassis/%s/Power: %s", chassis.ID, err)
-		} else if power != nil {
+		var power redfish.Power
+		var powerThresholds legacyResourceThresholds
+		if ok, err := entry.resource("Power", &power); err != nil {
+			return err
+		} else if ok {
+			if _, err := entry.resource("Power", &powerThresholds); err != nil {
+				return err
+			}
+
 			for _, control := range power.PowerControl {
 				c.processPowerControl(ch, control, chassis.ID)
 			}
 
-			for _, ps := range power.PowerSupplies {
-				c.processPowerSupply(ch, ps, chassis.ID)
+			if links.PowerSubsystem == "" {
+				for _, ps := range power.PowerSupplies {
+					c.processPowerSupply(ch, ps, chassis.ID)
+				}
 			}
 
-			for _, voltage := range power.Voltages {
-				c.processVoltage(ch, voltage, chassis.ID)
+			for i, voltage := range power.Voltages {
+				c.processVoltage(ch, voltage, thresholdsAt(powerThresholds.Voltages, i).thresholds(), chassis.ID)
 			}
 		}
 
+		var powerSubsystem powerSubsystem
+		if ok, err := entry.resource("PowerSubsystem", &powerSubsystem); err != nil {
+			return err
+		} else if ok {
+			if err := c.collectPowerSubsystem(ch, powerSubsystem, chassis.ID); err != nil {
+				return err
+			}
+		}
+
+		if links.EnvironmentMetrics != "" {
+			var metrics environmentMetrics
+			if err := getResource(c.client, string(links.EnvironmentMetrics), &metrics); err != nil {
+				return fmt.Errorf("error collecting /Chassis/%s/EnvironmentMetrics: %s", chassis.ID, err)
+			}
+			c.processEnvironmentMetrics(ch, metrics, chassis.ID)
+		}
+
 		adapters, err := chassis.NetworkAdapters()
 		if err != nil {
 			return fmt.Errorf("error collecting /Chassis/%s/NetworkAdapters: %s", chassis.ID, err)
@@ -69,13 +257,196 @@ func (c *ChassisCollector) Collect(ch chan<- prometheus.Metric) error {
 			for _, port := range ports {
 				c.processNetworkPort(ch, port, chassis.ID)
 			}
+
+			if err := c.collectNetworkAdapter(ch, adapter, chassis.ID); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
-func (c *ChassisCollector) processChassis(ch chan<- prometheus.Metric, chassis *redfish.Chassis) {
+// collectThermalSubsystem maps ThermalSubsystem fans and temperature readings
+// onto the metrics reported for the deprecated Thermal resource. Temperature
+// thresholds and status are taken from the sensors the readings link to.
+func (c *ChassisCollector) collectThermalSubsystem(ch chan<- prometheus.Metric, subsystem thermalSubsystem, sensors []sensor, chassisID string) error {
+	thermal := &redfish.Thermal{Status: subsystem.Status}
+	thermal.ID, thermal.Name = subsystem.ID, subsystem.Name
+	c.processThermal(ch, thermal, chassisID)
+
+	if subsystem.Fans != "" {
+		members, err := getMembers(c.client, string(subsystem.Fans))
+		if err != nil {
+			return fmt.Errorf("error collecting /Chassis/%s/ThermalSubsystem/Fans: %s", chassisID, err)
+		}
+
+		for _, member := range members {
+			var fan subsystemFan
+			if err := getResource(c.client, member, &fan); err != nil {
+				return fmt.Errorf("error collecting %s: %s", member, err)
+			}
+			c.processFan(ch, legacyFan(fan), sensorThresholds{}, chassisID)
+		}
+	}
+
+	if subsystem.ThermalMetrics != "" {
+		var metrics thermalMetrics
+		if err := getResource(c.client, string(subsystem.ThermalMetrics), &metrics); err != nil {
+			return fmt.Errorf("error collecting /Chassis/%s/ThermalSubsystem/ThermalMetrics: %s", chassisID, err)
+		}
+
+		sensorsByURI := make(map[string]sensor, len(sensors))
+		for _, s := range sensors {
+			sensorsByURI[strings.TrimSuffix(s.ODataID, "/")] = s
+		}
+
+		for i, t := range metrics.TemperatureReadingsCelsius {
+			if t.Reading == nil {
+				continue
+			}
+
+			temperature := redfish.Temperature{MemberID: t.DeviceName, PhysicalContext: t.PhysicalContext, ReadingCelsius: float32(*t.Reading)}
+			temperature.Name = t.DeviceName
+			if t.DataSourceURI != "" {
+				temperature.MemberID = path.Base(t.DataSourceURI)
+			} else if temperature.MemberID == "" {
+				temperature.MemberID = strconv.Itoa(i)
+			}
+
+			var thresholds sensorThresholds
+			if s, ok := sensorsByURI[strings.TrimSuffix(t.DataSourceURI, "/")]; ok && t.DataSourceURI != "" {
+				thresholds, temperature.Status = s.Thresholds, s.Status
+			}
+			c.processTemperature(ch, temperature, thresholds, chassisID)
+		}
+	}
+
+	return nil
+}
+
+// collectPowerSubsystem maps PowerSubsystem power supplies onto the metrics
+// reported for the deprecated Power resource, and collects batteries.
+func (c *ChassisCollector) collectPowerSubsystem(ch chan<- prometheus.Metric, subsystem powerSubsystem, chassisID string) error {
+	if subsystem.PowerSupplies != "" {
+		members, err := getMembers(c.client, string(subsystem.PowerSupplies))
+		if err != nil {
+			return fmt.Errorf("error collecting /Chassis/%s/PowerSubsystem/PowerSupplies: %s", chassisID, err)
+		}
+
+		for _, member := range members {
+			var ps subsystemPowerSupply
+			if err := getResource(c.client, member, &ps); err != nil {
+				return fmt.Errorf("error collecting %s: %s", member, err)
+			}
+
+			var metrics powerSupplyMetrics
+			if ps.Metrics != "" {
+				if err := getResource(c.client, string(ps.Metrics), &metrics); err != nil {
+					return fmt.Errorf("error collecting %s: %s", ps.Metrics, err)
+				}
+			}
+
+			c.processPowerSupply(ch, legacyPowerSupply(ps, metrics), chassisID)
+			c.processPowerSupplyMetrics(ch, ps, metrics, chassisID)
+		}
+	}
+
+	if subsystem.Batteries != "" {
+		members, err := getMembers(c.client, string(subsystem.Batteries))
+		if err != nil {
+			return fmt.Errorf("error collecting /Chassis/%s/PowerSubsystem/Batteries: %s", chassisID, err)
+		}
+
+		for _, member := range members {
+			var b battery
+			if err := getResource(c.client, member, &b); err != nil {
+				return fmt.Errorf("error collecting %s: %s", member, err)
+			}
+			c.processBattery(ch, b, chassisID)
+		}
+	}
+
+	return nil
+}
+
+func (c *ChassisCollector) collectNetworkAdapter(ch chan<- prometheus.Metric, adapter *redfish.NetworkAdapter, chassisID string) error {
+	var links networkAdapterLinks
+	if err := getResource(c.client, adapter.ODataID, &links); err != nil {
+		return fmt.Errorf("error collecting /Chassis/%s/NetworkAdapters/%s: %s", chassisID, adapter.ID, err)
+	}
+
+	if links.Metrics != "" {
+		var metrics networkAdapterMetrics
+		if err := getResource(c.client, string(links.Metrics), &metrics); err != nil {
+			return fmt.Errorf("error collecting /Chassis/%s/NetworkAdapters/%s/Metrics: %s", chassisID, adapter.ID, err)
+		}
+		c.processNetworkAdapterMetrics(ch, adapter, metrics, chassisID)
+	}
+
+	if links.Ports != "" {
+		members, err := getMembers(c.client, string(links.Ports))
+		if err != nil {
+			return fmt.Errorf("error collecting /Chassis/%s/NetworkAdapters/%s/Ports: %s", chassisID, adapter.ID, err)
+		}
+
+		for _, member := range members {
+			var p port
+			if err := getResource(c.client, member, &p); err != nil {
+				return fmt.Errorf("error collecting %s: %s", member, err)
+			}
+
+			if p.Metrics != "" {
+				var metrics portMetrics
+				if err := getResource(c.client, string(p.Metrics), &metrics); err != nil {
+					return fmt.Errorf("error collecting %s: %s", p.Metrics, err)
+				}
+				c.processPortMetrics(ch, p, metrics, adapter.ID, chassisID)
+			}
+		}
+	}
+
+	if links.NetworkDeviceFunctions != "" {
+		members, err := getMembers(c.client, string(links.NetworkDeviceFunctions))
+		if err != nil {
+			return fmt.Errorf("error collecting /Chassis/%s/NetworkAdapters/%s/NetworkDeviceFunctions: %s", chassisID, adapter.ID, err)
+		}
+
+		for _, member := range members {
+			var function networkDeviceFunction
+			if err := getResource(c.client, member, &function); err != nil {
+				return fmt.Errorf("error collecting %s: %s", member, err)
+			}
+
+			var metrics networkDeviceFunctionMetrics
+			if function.Metrics != "" {
+				if err := getResource(c.client, string(function.Metrics), &metrics); err != nil {
+					return fmt.Errorf("error collecting %s: %s", function.Metrics, err)
+				}
+			}
+
+			// the IP configuration is held by the system Ethernet interface
+			// the function backs
+			interfaceLinks := function.Links.EthernetInterfaces
+			if function.Links.EthernetInterface != "" {
+				interfaceLinks = append(interfaceLinks, function.Links.EthernetInterface)
+			}
+			var interfaces []*redfish.EthernetInterface
+			for _, link := range interfaceLinks {
+				intf, err := redfish.GetEthernetInterface(c.client, string(link))
+				if err != nil {
+					return fmt.Errorf("error collecting %s: %s", link, err)
+				}
+				interfaces = append(interfaces, intf)
+			}
+			c.processNetworkDeviceFunction(ch, function, metrics, interfaces, adapter.ID, chassisID)
+		}
+	}
+
+	return nil
+}
+
+func (c *ChassisCollector) processChassis(ch chan<- prometheus.Metric, chassis *redfish.Chassis, powerTransitions float64) {
 	constLabels := prometheus.Labels{"id": chassis.ID, "name": chassis.Name, "chassis_id": chassis.ID, "chassis_type": string(chassis.ChassisType)}
 
 	intrusionSensorDesc := prometheus.NewDesc(
@@ -88,6 +459,11 @@ func (c *ChassisCollector) processChassis(ch chan<- prometheus.Metric, chassis *
 		"Chassis power state; 0: Off, 1: On, 2: PoweringOn, 3: PoweringOff",
 		nil, constLabels,
 	)
+	powerTransitionsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "power_transitions_total"),
+		"Chassis power transitions between On and Off observed by the exporter",
+		nil, constLabels,
+	)
 
 	healthDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "chassis", "health"),
@@ -106,6 +482,7 @@ func (c *ChassisCollector) processChassis(ch chan<- prometheus.Metric, chassis *
 	if e := enumPowerState(chassis.PowerState); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(powerStateDesc, prometheus.GaugeValue, e)
 	}
+	ch <- prometheus.MustNewConstMetric(powerTransitionsDesc, prometheus.CounterValue, powerTransitions)
 
 	if e := enumHealth(chassis.Status.Health); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
@@ -137,7 +514,7 @@ func (c *ChassisCollector) processThermal(ch chan<- prometheus.Metric, thermal *
 	}
 }
 
-func (c *ChassisCollector) processFan(ch chan<- prometheus.Metric, fan redfish.Fan, chassisID string) {
+func (c *ChassisCollector) processFan(ch chan<- prometheus.Metric, fan redfish.Fan, thresholds sensorThresholds, chassisID string) {
 	constLabels := prometheus.Labels{"id": fan.MemberID, "name": fan.Name, "chassis_id": chassisID, "sensor_number": strconv.Itoa(fan.SensorNumber), "physical_context": fan.PhysicalContext}
 
 	readingRPMDesc := prometheus.NewDesc(
@@ -151,6 +528,22 @@ func (c *ChassisCollector) processFan(ch chan<- prometheus.Metric, fan redfish.F
 		nil, constLabels,
 	)
 
+	thresholdRPMDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "fan_speed_threshold_rpm"),
+		"Fan speed threshold, RPM",
+		[]string{"threshold", "direction"}, constLabels,
+	)
+	thresholdPercentDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "fan_speed_threshold_ratio"),
+		"Fan speed threshold, %",
+		[]string{"threshold", "direction"}, constLabels,
+	)
+	breachedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "sensor_threshold_breached"),
+		"Most severe sensor threshold breached; 0: None, 1: Caution, 2: Critical, 3: Fatal",
+		[]string{"sensor_type"}, constLabels,
+	)
+
 	healthDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "chassis", "fan_health"),
 		"Fan health; 0: OK, 1: Warning, 2: Critical",
@@ -162,11 +555,26 @@ func (c *ChassisCollector) processFan(ch chan<- prometheus.Metric, fan redfish.F
 		nil, constLabels,
 	)
 
+	// absent or unread fans report a reading of 0, which is not a breach
+	enabled := fan.Status.State == common.EnabledState
+
 	switch fan.ReadingUnits {
 	case redfish.RPMReadingUnits:
 		ch <- prometheus.MustNewConstMetric(readingRPMDesc, prometheus.GaugeValue, float64(fan.Reading))
+		for _, t := range thresholds.readings() {
+			ch <- prometheus.MustNewConstMetric(thresholdRPMDesc, prometheus.GaugeValue, t.reading, t.threshold, t.direction)
+		}
+		if enabled {
+			ch <- prometheus.MustNewConstMetric(breachedDesc, prometheus.GaugeValue, thresholds.breached(float64(fan.Reading)), "fan")
+		}
 	case redfish.PercentReadingUnits:
 		ch <- prometheus.MustNewConstMetric(readingPercentDesc, prometheus.GaugeValue, float64(fan.Reading)/100)
+		for _, t := range thresholds.readings() {
+			ch <- prometheus.MustNewConstMetric(thresholdPercentDesc, prometheus.GaugeValue, t.reading/100, t.threshold, t.direction)
+		}
+		if enabled {
+			ch <- prometheus.MustNewConstMetric(breachedDesc, prometheus.GaugeValue, thresholds.breached(float64(fan.Reading)), "fan")
+		}
 	}
 
 	if e := enumHealth(fan.Status.Health); e >= 0 {
@@ -177,7 +585,7 @@ func (c *ChassisCollector) processFan(ch chan<- prometheus.Metric, fan redfish.F
 	}
 }
 
-func (c *ChassisCollector) processTemperature(ch chan<- prometheus.Metric, t redfish.Temperature, chassisID string) {
+func (c *ChassisCollector) processTemperature(ch chan<- prometheus.Metric, t redfish.Temperature, thresholds sensorThresholds, chassisID string) {
 	constLabels := prometheus.Labels{"id": t.MemberID, "name": t.Name, "chassis_id": chassisID, "sensor_number": strconv.Itoa(t.SensorNumber), "physical_context": t.PhysicalContext}
 
 	readingDesc := prometheus.NewDesc(
@@ -186,6 +594,17 @@ func (c *ChassisCollector) processTemperature(ch chan<- prometheus.Metric, t red
 		nil, constLabels,
 	)
 
+	thresholdDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "temperature_threshold_celsius"),
+		"Temperature sensor threshold, °C",
+		[]string{"threshold", "direction"}, constLabels,
+	)
+	breachedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "sensor_threshold_breached"),
+		"Most severe sensor threshold breached; 0: None, 1: Caution, 2: Critical, 3: Fatal",
+		[]string{"sensor_type"}, constLabels,
+	)
+
 	healthDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "chassis", "temperature_health"),
 		"Temperature sensor health; 0: OK, 1: Warning, 2: Critical",
@@ -199,6 +618,13 @@ func (c *ChassisCollector) processTemperature(ch chan<- prometheus.Metric, t red
 
 	ch <- prometheus.MustNewConstMetric(readingDesc, prometheus.GaugeValue, float64(t.ReadingCelsius))
 
+	for _, r := range thresholds.readings() {
+		ch <- prometheus.MustNewConstMetric(thresholdDesc, prometheus.GaugeValue, r.reading, r.threshold, r.direction)
+	}
+	if t.Status.State == common.EnabledState {
+		ch <- prometheus.MustNewConstMetric(breachedDesc, prometheus.GaugeValue, thresholds.breached(float64(t.ReadingCelsius)), "temperature")
+	}
+
 	if e := enumHealth(t.Status.Health); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
 	}
@@ -235,6 +661,26 @@ func (c *ChassisCollector) processPowerControl(ch chan<- prometheus.Metric, cont
 		"Power requested by the chassis resources, W",
 		nil, constLabels,
 	)
+	powerAverageDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "power_control_power_consumed_average_watts"),
+		"Average power consumed by the chassis resources over the measurement interval, W",
+		nil, constLabels,
+	)
+	powerMaxDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "power_control_power_consumed_max_watts"),
+		"Maximum power consumed by the chassis resources over the measurement interval, W",
+		nil, constLabels,
+	)
+	powerMinDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "power_control_power_consumed_min_watts"),
+		"Minimum power consumed by the chassis resources over the measurement interval, W",
+		nil, constLabels,
+	)
+	intervalDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "power_control_power_metrics_interval_seconds"),
+		"Power consumption measurement interval, s",
+		nil, constLabels,
+	)
 
 	healthDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "chassis", "power_control_health"),
@@ -253,6 +699,13 @@ func (c *ChassisCollector) processPowerControl(ch chan<- prometheus.Metric, cont
 	ch <- prometheus.MustNewConstMetric(powerLimitDesc, prometheus.GaugeValue, float64(control.PowerLimit.LimitInWatts), strconv.FormatInt(control.PowerLimit.CorrectionInMs/1000, 10), string(control.PowerLimit.LimitException))
 	ch <- prometheus.MustNewConstMetric(powerRequestedDesc, prometheus.GaugeValue, float64(control.PowerRequestedWatts))
 
+	if control.PowerMetrics.IntervalInMin > 0 {
+		ch <- prometheus.MustNewConstMetric(powerAverageDesc, prometheus.GaugeValue, float64(control.PowerMetrics.AverageConsumedWatts))
+		ch <- prometheus.MustNewConstMetric(powerMaxDesc, prometheus.GaugeValue, float64(control.PowerMetrics.MaxConsumedWatts))
+		ch <- prometheus.MustNewConstMetric(powerMinDesc, prometheus.GaugeValue, float64(control.PowerMetrics.MinConsumedWatts))
+		ch <- prometheus.MustNewConstMetric(intervalDesc, prometheus.GaugeValue, float64(control.PowerMetrics.IntervalInMin)*60)
+	}
+
 	if e := enumHealth(control.Status.Health); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
 	}
@@ -301,7 +754,10 @@ func (c *ChassisCollector) processPowerSupply(ch chan<- prometheus.Metric, ps re
 		nil, constLabels,
 	)
 
-	ch <- prometheus.MustNewConstMetric(efficiencyDesc, prometheus.GaugeValue, float64(ps.EfficiencyPercent)/100)
+	// PowerSubsystem only reports rated efficiency, which is not a measurement
+	if ps.EfficiencyPercent != 0 {
+		ch <- prometheus.MustNewConstMetric(efficiencyDesc, prometheus.GaugeValue, float64(ps.EfficiencyPercent)/100)
+	}
 	ch <- prometheus.MustNewConstMetric(inputVoltageDesc, prometheus.GaugeValue, float64(ps.LineInputVoltage), string(ps.LineInputVoltageType))
 	ch <- prometheus.MustNewConstMetric(powerCapacityDesc, prometheus.GaugeValue, float64(ps.PowerCapacityWatts))
 	ch <- prometheus.MustNewConstMetric(powerInputDesc, prometheus.GaugeValue, float64(ps.PowerInputWatts))
@@ -316,7 +772,85 @@ func (c *ChassisCollector) processPowerSupply(ch chan<- prometheus.Metric, ps re
 
 }
 
-func (c *ChassisCollector) processVoltage(ch chan<- prometheus.Metric, voltage redfish.Voltage, chassisID string) {
+func (c *ChassisCollector) processPowerSupplyMetrics(ch chan<- prometheus.Metric, ps subsystemPowerSupply, metrics powerSupplyMetrics, chassisID string) {
+	constLabels := prometheus.Labels{"id": ps.ID, "name": ps.Name, "chassis_id": chassisID, "power_supply_type": string(ps.PowerSupplyType)}
+
+	energyDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "power_supply_energy_joules_total"),
+		"Power supply cumulative energy consumption, J",
+		nil, constLabels,
+	)
+
+	if metrics.EnergykWh.Reading != nil {
+		ch <- prometheus.MustNewConstMetric(energyDesc, prometheus.CounterValue, *metrics.EnergykWh.Reading*kilowattHour)
+	}
+}
+
+func (c *ChassisCollector) processEnvironmentMetrics(ch chan<- prometheus.Metric, metrics environmentMetrics, chassisID string) {
+	constLabels := prometheus.Labels{"id": metrics.ID, "name": metrics.Name, "chassis_id": chassisID}
+
+	energyDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "energy_joules_total"),
+		"Chassis cumulative energy consumption, J",
+		nil, constLabels,
+	)
+
+	if metrics.EnergyJoules.Reading != nil {
+		ch <- prometheus.MustNewConstMetric(energyDesc, prometheus.CounterValue, *metrics.EnergyJoules.Reading)
+	} else if metrics.EnergykWh.Reading != nil {
+		ch <- prometheus.MustNewConstMetric(energyDesc, prometheus.CounterValue, *metrics.EnergykWh.Reading*kilowattHour)
+	}
+}
+
+func (c *ChassisCollector) processBattery(ch chan<- prometheus.Metric, b battery, chassisID string) {
+	constLabels := prometheus.Labels{"id": b.ID, "name": b.Name, "chassis_id": chassisID}
+
+	capacityDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "battery_capacity_watt_hours"),
+		"Battery actual capacity, Wh",
+		nil, constLabels,
+	)
+	chargeStateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "battery_charge_state"),
+		"Battery charge state; 0: Idle, 1: Charging, 2: Discharging",
+		nil, constLabels,
+	)
+	stateOfHealthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "battery_state_of_health_ratio"),
+		"Battery state of health, %",
+		nil, constLabels,
+	)
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "battery_health"),
+		"Battery health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "battery_state"),
+		"Battery state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	if b.CapacityActualWattHours != nil {
+		ch <- prometheus.MustNewConstMetric(capacityDesc, prometheus.GaugeValue, *b.CapacityActualWattHours)
+	}
+	if b.StateOfHealthPercent.Reading != nil {
+		ch <- prometheus.MustNewConstMetric(stateOfHealthDesc, prometheus.GaugeValue, *b.StateOfHealthPercent.Reading/100)
+	}
+	if e := enumChargeState(b.ChargeState); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(chargeStateDesc, prometheus.GaugeValue, e)
+	}
+
+	if e := enumHealth(b.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(b.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
+}
+
+func (c *ChassisCollector) processVoltage(ch chan<- prometheus.Metric, voltage redfish.Voltage, thresholds sensorThresholds, chassisID string) {
 	constLabels := prometheus.Labels{"id": voltage.MemberID, "name": voltage.Name, "chassis_id": chassisID, "sensor_number": strconv.Itoa(voltage.SensorNumber), "physical_context": voltage.PhysicalContext}
 
 	readingDesc := prometheus.NewDesc(
@@ -325,6 +859,17 @@ func (c *ChassisCollector) processVoltage(ch chan<- prometheus.Metric, voltage r
 		nil, constLabels,
 	)
 
+	thresholdDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "voltage_threshold_volts"),
+		"Voltage sensor threshold, V",
+		[]string{"threshold", "direction"}, constLabels,
+	)
+	breachedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "sensor_threshold_breached"),
+		"Most severe sensor threshold breached; 0: None, 1: Caution, 2: Critical, 3: Fatal",
+		[]string{"sensor_type"}, constLabels,
+	)
+
 	healthDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "chassis", "voltage_health"),
 		"Voltage sensor health; 0: OK, 1: Warning, 2: Critical",
@@ -338,6 +883,13 @@ func (c *ChassisCollector) processVoltage(ch chan<- prometheus.Metric, voltage r
 
 	ch <- prometheus.MustNewConstMetric(readingDesc, prometheus.GaugeValue, float64(voltage.ReadingVolts))
 
+	for _, t := range thresholds.readings() {
+		ch <- prometheus.MustNewConstMetric(thresholdDesc, prometheus.GaugeValue, t.reading, t.threshold, t.direction)
+	}
+	if voltage.Status.State == common.EnabledState {
+		ch <- prometheus.MustNewConstMetric(breachedDesc, prometheus.GaugeValue, thresholds.breached(float64(voltage.ReadingVolts)), "voltage")
+	}
+
 	if e := enumHealth(voltage.Status.Health); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
 	}
@@ -407,6 +959,260 @@ func (c *ChassisCollector) processNetworkPort(ch chan<- prometheus.Metric, port
 	}
 }
 
+func (c *ChassisCollector) processNetworkAdapterMetrics(ch chan<- prometheus.Metric, adapter *redfish.NetworkAdapter, metrics networkAdapterMetrics, chassisID string) {
+	constLabels := prometheus.Labels{"id": adapter.ID, "name": adapter.Name, "chassis_id": chassisID}
+
+	rxBytesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_adapter_receive_bytes_total"),
+		"Network adapter received bytes",
+		nil, constLabels,
+	)
+	txBytesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_adapter_transmit_bytes_total"),
+		"Network adapter transmitted bytes",
+		nil, constLabels,
+	)
+	rxFramesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_adapter_receive_frames_total"),
+		"Network adapter received frames",
+		[]string{"type"}, constLabels,
+	)
+	txFramesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_adapter_transmit_frames_total"),
+		"Network adapter transmitted frames",
+		[]string{"type"}, constLabels,
+	)
+
+	if v := metrics.RXBytes; v != nil {
+		ch <- prometheus.MustNewConstMetric(rxBytesDesc, prometheus.CounterValue, *v)
+	}
+	if v := metrics.TXBytes; v != nil {
+		ch <- prometheus.MustNewConstMetric(txBytesDesc, prometheus.CounterValue, *v)
+	}
+	if v := metrics.RXUnicastFrames; v != nil {
+		ch <- prometheus.MustNewConstMetric(rxFramesDesc, prometheus.CounterValue, *v, "unicast")
+	}
+	if v := metrics.RXMulticastFrames; v != nil {
+		ch <- prometheus.MustNewConstMetric(rxFramesDesc, prometheus.CounterValue, *v, "multicast")
+	}
+	if v := metrics.TXUnicastFrames; v != nil {
+		ch <- prometheus.MustNewConstMetric(txFramesDesc, prometheus.CounterValue, *v, "unicast")
+	}
+	if v := metrics.TXMulticastFrames; v != nil {
+		ch <- prometheus.MustNewConstMetric(txFramesDesc, prometheus.CounterValue, *v, "multicast")
+	}
+}
+
+func (c *ChassisCollector) processPortMetrics(ch chan<- prometheus.Metric, p port, metrics portMetrics, adapterID string, chassisID string) {
+	constLabels := prometheus.Labels{"id": p.ID, "name": p.Name, "chassis_id": chassisID, "adapter_id": adapterID}
+
+	rxBytesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_port_receive_bytes_total"),
+		"Network port received bytes",
+		nil, constLabels,
+	)
+	txBytesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_port_transmit_bytes_total"),
+		"Network port transmitted bytes",
+		nil, constLabels,
+	)
+	rxFramesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_port_receive_frames_total"),
+		"Network port received frames",
+		nil, constLabels,
+	)
+	txFramesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_port_transmit_frames_total"),
+		"Network port transmitted frames",
+		nil, constLabels,
+	)
+	rxErrorsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_port_receive_errors_total"),
+		"Network port receive errors",
+		nil, constLabels,
+	)
+	txErrorsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_port_transmit_errors_total"),
+		"Network port transmit errors",
+		nil, constLabels,
+	)
+	rxCRCErrorsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_port_receive_crc_errors_total"),
+		"Network port frames received with CRC/FCS errors",
+		nil, constLabels,
+	)
+	rxDiscardsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_port_receive_discards_total"),
+		"Network port discarded received frames",
+		nil, constLabels,
+	)
+	txDiscardsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_port_transmit_discards_total"),
+		"Network port discarded frames to transmit",
+		nil, constLabels,
+	)
+
+	for _, counter := range []struct {
+		desc  *prometheus.Desc
+		value *float64
+	}{
+		{rxBytesDesc, metrics.RXBytes},
+		{txBytesDesc, metrics.TXBytes},
+		{rxFramesDesc, metrics.Networking.RXFrames},
+		{txFramesDesc, metrics.Networking.TXFrames},
+		{rxErrorsDesc, metrics.RXErrors},
+		{txErrorsDesc, metrics.TXErrors},
+		{rxCRCErrorsDesc, metrics.Networking.RXFCSErrors},
+		{rxDiscardsDesc, metrics.Networking.RXDiscards},
+		{txDiscardsDesc, metrics.Networking.TXDiscards},
+	} {
+		if counter.value != nil {
+			ch <- prometheus.MustNewConstMetric(counter.desc, prometheus.CounterValue, *counter.value)
+		}
+	}
+}
+
+func (c *ChassisCollector) processNetworkDeviceFunction(ch chan<- prometheus.Metric, function networkDeviceFunction, metrics networkDeviceFunctionMetrics, interfaces []*redfish.EthernetInterface, adapterID string, chassisID string) {
+	constLabels := prometheus.Labels{"id": function.ID, "name": function.Name, "chassis_id": chassisID, "adapter_id": adapterID}
+
+	infoDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_device_function_info"),
+		"Network device function configuration",
+		[]string{"type", "mac_address", "vlan_id", "iscsi_initiator_address"}, constLabels,
+	)
+	addressDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_device_function_address_info"),
+		"Network device function IP address configuration, from the linked Ethernet interface",
+		[]string{"ethernet_interface_id", "address_family", "ip_address", "prefix_length", "origin", "gateway"}, constLabels,
+	)
+	mtuDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_device_function_mtu_bytes"),
+		"Network device function MTU, bytes",
+		nil, constLabels,
+	)
+	rxBytesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_device_function_receive_bytes_total"),
+		"Network device function received bytes",
+		nil, constLabels,
+	)
+	txBytesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_device_function_transmit_bytes_total"),
+		"Network device function transmitted bytes",
+		nil, constLabels,
+	)
+	rxFramesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_device_function_receive_frames_total"),
+		"Network device function received frames",
+		nil, constLabels,
+	)
+	txFramesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_device_function_transmit_frames_total"),
+		"Network device function transmitted frames",
+		nil, constLabels,
+	)
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_device_function_health"),
+		"Network device function health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "chassis", "network_device_function_state"),
+		"Network device function state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	var macAddress, vlanID string
+	if function.Ethernet.MACAddress != nil {
+		macAddress = strings.ToLower(*function.Ethernet.MACAddress)
+	}
+	if vlan := function.Ethernet.VLAN; vlan.VLANEnable && vlan.VLANID != nil {
+		vlanID = strconv.Itoa(int(*vlan.VLANID))
+	}
+	ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1, function.NetDevFuncType, macAddress, vlanID, function.ISCSIBoot.InitiatorIPAddress)
+
+	for _, intf := range interfaces {
+		for _, a := range interfaceAddresses(intf) {
+			ch <- prometheus.MustNewConstMetric(addressDesc, prometheus.GaugeValue, 1, intf.ID, a.family, a.address, a.prefixLength, a.origin, a.gateway)
+		}
+	}
+
+	if v := function.Ethernet.MTUSize; v != nil {
+		ch <- prometheus.MustNewConstMetric(mtuDesc, prometheus.GaugeValue, *v)
+	}
+
+	for _, counter := range []struct {
+		desc  *prometheus.Desc
+		value *float64
+	}{
+		{rxBytesDesc, metrics.RXBytes},
+		{txBytesDesc, metrics.TXBytes},
+		{rxFramesDesc, metrics.RXFrames},
+		{txFramesDesc, metrics.TXFrames},
+	} {
+		if counter.value != nil {
+			ch <- prometheus.MustNewConstMetric(counter.desc, prometheus.CounterValue, *counter.value)
+		}
+	}
+
+	if e := enumHealth(function.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(function.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
+}
+
+func legacyFan(fan subsystemFan) redfish.Fan {
+	f := redfish.Fan{MemberID: fan.ID, PhysicalContext: fan.PhysicalContext, Status: fan.Status}
+	f.Name = fan.Name
+
+	// prefer RPM, as reported by most Thermal implementations
+	if fan.SpeedPercent.SpeedRPM != nil {
+		f.Reading, f.ReadingUnits = float32(*fan.SpeedPercent.SpeedRPM), redfish.RPMReadingUnits
+	} else if fan.SpeedPercent.Reading != nil {
+		f.Reading, f.ReadingUnits = float32(*fan.SpeedPercent.Reading), redfish.PercentReadingUnits
+	}
+
+	return f
+}
+
+func legacyPowerSupply(ps subsystemPowerSupply, metrics powerSupplyMetrics) redfish.PowerSupply {
+	p := redfish.PowerSupply{
+		MemberID:             ps.ID,
+		LineInputVoltageType: redfish.LineInputVoltageType(ps.InputNominalVoltageType),
+		PowerCapacityWatts:   ps.PowerCapacityWatts,
+		PowerSupplyType:      ps.PowerSupplyType,
+		Status:               ps.Status,
+	}
+	p.Name = ps.Name
+
+	if metrics.InputVoltage.Reading != nil {
+		p.LineInputVoltage = float32(*metrics.InputVoltage.Reading)
+	}
+	if metrics.InputPowerWatts.Reading != nil {
+		p.PowerInputWatts = float32(*metrics.InputPowerWatts.Reading)
+	}
+	if metrics.OutputPowerWatts.Reading != nil {
+		p.PowerOutputWatts = float32(*metrics.OutputPowerWatts.Reading)
+	}
+
+	return p
+}
+
+func enumChargeState(e string) float64 {
+	switch e {
+	case "Idle":
+		return 0
+	case "Charging":
+		return 1
+	case "Discharging":
+		return 2
+	default:
+		return -1
+	}
+}
+
 func enumIntrusionSensor(e redfish.IntrusionSensor) float64 {
 	switch e {
 	case redfish.NormalIntrusionSensor:
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"fmt"
+	"github.com/pallasscat/redfish_exporter/config"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stmcginnis/gofish"
 	"log"
@@ -16,13 +17,21 @@ type Collector interface {
 }
 
 type RedfishCollector struct {
-	config gofish.ClientConfig
-	upDesc *prometheus.Desc
+	config     gofish.ClientConfig
+	state      *State
+	bios       config.BIOSBaseline
+	reports    []string
+	timestamps bool
+	upDesc     *prometheus.Desc
 }
 
-func NewRedfishCollector(config gofish.ClientConfig) *RedfishCollector {
+func NewRedfishCollector(config gofish.ClientConfig, state *State, bios config.BIOSBaseline, reports []string, reportTimestamps bool) *RedfishCollector {
 	return &RedfishCollector{
-		config: config,
+		config:     config,
+		state:      state,
+		bios:       bios,
+		reports:    reports,
+		timestamps: reportTimestamps,
 		upDesc: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "up"),
 			"Redfish service status; 0: Down, 1: Up",
@@ -38,7 +47,16 @@ func (c *RedfishCollector) Describe(ch chan<- *prometheus.Desc) {
 func (c *RedfishCollector) Collect(ch chan<- prometheus.Metric) {
 	log.SetPrefix(fmt.Sprintf("endpoint %s: ", c.config.Endpoint))
 
-	client, err := gofish.Connect(c.config)
+	var peer peerCertificate
+	clientConfig := c.config
+	if clientConfig.HTTPClient == nil {
+		clientConfig.HTTPClient = peer.httpClient(clientConfig)
+	}
+
+	// reported even if connecting fails, as an expired certificate may be why
+	defer peer.process(ch)
+
+	client, err := gofish.Connect(clientConfig)
 	if err != nil {
 		log.Printf("error connecting to Redfish server: %s", err)
 		ch <- prometheus.MustNewConstMetric(c.upDesc, prometheus.GaugeValue, 0)
@@ -46,10 +64,20 @@ func (c *RedfishCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 	defer client.Logout()
 
+	target := targetState{c.state, c.config.Endpoint}
+	inventory := &chassisInventory{client: client}
+	bios := &biosInventory{}
+
 	collectors := map[string]Collector{
-		"chassis": &ChassisCollector{client},
-		"system":  &SystemCollector{client},
-		"manager": &ManagerCollector{client},
+		"chassis":     &ChassisCollector{client, target, inventory},
+		"system":      &SystemCollector{client, target, bios},
+		"manager":     &ManagerCollector{client},
+		"redundancy":  &RedundancyCollector{client, inventory},
+		"sensor":      &SensorCollector{client, inventory},
+		"bios":        &BIOSCollector{client, c.bios, bios},
+		"certificate": &CertificateCollector{client},
+		"task":        &TaskCollector{client},
+		"telemetry":   &TelemetryCollector{client, c.reports, c.timestamps},
 	}
 
 	wg := sync.WaitGroup{}
@@ -62,6 +90,10 @@ func (c *RedfishCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 	wg.Wait()
 
+	if err := c.state.Save(); err != nil {
+		log.Printf("error saving state: %s", err)
+	}
+
 	ch <- prometheus.MustNewConstMetric(c.upDesc, prometheus.GaugeValue, 1)
 }
 
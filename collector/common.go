@@ -1,8 +1,13 @@
 package collector
 
 import (
+	"encoding/json"
 	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
+	"net"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -17,6 +22,148 @@ const (
 	giga         = 1000 * mega
 )
 
+// kilowattHour is a kWh in joules.
+const kilowattHour = 3600 * kilo
+
+// metricsLink holds the link to the metrics of a resource, for resources
+// gofish does not expose it for.
+type metricsLink struct {
+	Metrics common.Link
+}
+
+// serviceRootLinks holds the service root links gofish does not expose.
+type serviceRootLinks struct {
+	AccountService     common.Link
+	CertificateService common.Link
+	JobService         common.Link
+	SessionService     common.Link
+	TelemetryService   common.Link
+	// Tasks links to the TaskService, not to the task collection
+	Tasks common.Link
+}
+
+// getResource fetches the resource at uri and decodes it into v, for schemas
+// not (yet) modelled by gofish.
+func getResource(client common.Client, uri string, v interface{}) error {
+	resp, err := client.Get(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// getMembers returns the member links of the collection at uri.
+func getMembers(client common.Client, uri string) ([]string, error) {
+	collection, err := common.GetCollection(client, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.ItemLinks, nil
+}
+
+// parseDuration parses an ISO 8601 duration, as used by Redfish, into
+// seconds. Years and months are not supported.
+func parseDuration(s string) (float64, bool) {
+	if !strings.HasPrefix(s, "P") {
+		return 0, false
+	}
+
+	var seconds float64
+	var inTime bool
+	var number string
+	for _, r := range s[1:] {
+		switch {
+		case r == 'T':
+			inTime = true
+		case r >= '0' && r <= '9' || r == '.':
+			number += string(r)
+		default:
+			v, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, false
+			}
+			number = ""
+
+			switch {
+			case r == 'W' && !inTime:
+				seconds += v * 7 * 24 * 3600
+			case r == 'D' && !inTime:
+				seconds += v * 24 * 3600
+			case r == 'H' && inTime:
+				seconds += v * 3600
+			case r == 'M' && inTime:
+				seconds += v * 60
+			case r == 'S' && inTime:
+				seconds += v
+			default:
+				return 0, false
+			}
+		}
+	}
+
+	return seconds, number == ""
+}
+
+// parseTime parses a Redfish timestamp into seconds since epoch.
+func parseTime(s string) (float64, bool) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, false
+	}
+
+	return float64(t.UnixNano()) / 1e9, true
+}
+
+// interfaceAddress holds the label values of an IP address configured on an
+// Ethernet interface.
+type interfaceAddress struct {
+	family       string
+	address      string
+	prefixLength string
+	origin       string
+	gateway      string
+}
+
+// interfaceAddresses returns the IPv4 and IPv6 addresses of intf.
+func interfaceAddresses(intf *redfish.EthernetInterface) []interfaceAddress {
+	var result []interfaceAddress
+	for _, a := range intf.IPv4Addresses {
+		if a.Address == "" {
+			continue
+		}
+
+		var prefixLength string
+		if mask := net.ParseIP(a.SubnetMask).To4(); mask != nil {
+			ones, _ := net.IPMask(mask).Size()
+			prefixLength = strconv.Itoa(ones)
+		}
+		result = append(result, interfaceAddress{"ipv4", a.Address, prefixLength, string(a.AddressOrigin), a.Gateway})
+	}
+
+	for _, a := range intf.IPv6Addresses {
+		if a.Address == "" {
+			continue
+		}
+
+		result = append(result, interfaceAddress{"ipv6", a.Address, strconv.Itoa(int(a.PrefixLength)), string(a.AddressOrigin), intf.IPv6DefaultGateway})
+	}
+
+	return result
+}
+
+// interfaceVLAN returns the VLAN ID of intf, or an empty string if it is not
+// VLAN tagged.
+func interfaceVLAN(intf *redfish.EthernetInterface) string {
+	if !intf.VLAN.VLANEnable {
+		return ""
+	}
+
+	return strconv.Itoa(int(intf.VLAN.VLANID))
+}
+
 func btof(b bool) float64 {
 	if b {
 		return 1
@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"testing"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     float64
+		ok       bool
+	}{
+		{"PT0S", 0, true},
+		{"PT10.5S", 10.5, true},
+		{"PT1M2S", 62, true},
+		{"PT2H", 7200, true},
+		{"P1D", 86400, true},
+		{"P1DT1H1M1S", 90061, true},
+		{"P2W", 1209600, true},
+		{"P1Y", 0, false},
+		{"P1M", 0, false},
+		{"P1H", 0, false},
+		{"PT1D", 0, false},
+		{"PT5", 0, false},
+		{"PTS", 0, false},
+		{"10S", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseDuration(tt.duration)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseDuration(%q) = %v, %v, want %v, %v", tt.duration, got, ok, tt.want, tt.ok)
+		}
+	}
+}
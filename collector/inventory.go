@@ -0,0 +1,140 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"io"
+	"sync"
+)
+
+// chassisInventory fetches the chassis, along with the Power, Thermal and
+// Sensor resources read by several collectors, once per scrape.
+type chassisInventory struct {
+	client  *gofish.APIClient
+	once    sync.Once
+	entries []chassisEntry
+	err     error
+}
+
+type chassisEntry struct {
+	chassis *redfish.Chassis
+	links   chassisLinks
+	// resources holds the Power, PowerSubsystem, Thermal and ThermalSubsystem
+	// resources as returned by the service
+	resources map[string][]byte
+	sensors   []sensor
+}
+
+// get returns the chassis, fetching them on the first call.
+func (i *chassisInventory) get() ([]chassisEntry, error) {
+	i.once.Do(func() {
+		i.entries, i.err = i.fetch()
+	})
+
+	return i.entries, i.err
+}
+
+func (i *chassisInventory) fetch() ([]chassisEntry, error) {
+	chassiss, err := i.client.Service.Chassis()
+	if err != nil {
+		return nil, fmt.Errorf("error collecting /Chassis: %s", err)
+	}
+
+	entries := make([]chassisEntry, 0, len(chassiss))
+	for _, chassis := range chassiss {
+		entry := chassisEntry{chassis: chassis, resources: make(map[string][]byte)}
+		if err := getResource(i.client, chassis.ODataID, &entry.links); err != nil {
+			return nil, fmt.Errorf("error collecting /Chassis/%s: %s", chassis.ID, err)
+		}
+
+		resources := map[string]common.Link{
+			"Power":            entry.links.Power,
+			"PowerSubsystem":   entry.links.PowerSubsystem,
+			"Thermal":          entry.links.Thermal,
+			"ThermalSubsystem": entry.links.ThermalSubsystem,
+		}
+		for name, link := range resources {
+			if link == "" {
+				continue
+			}
+
+			b, err := getRawResource(i.client, string(link))
+			if err != nil {
+				return nil, fmt.Errorf("error collecting /Chassis/%s/%s: %s", chassis.ID, name, err)
+			}
+			entry.resources[name] = b
+		}
+
+		if entry.links.Sensors != "" {
+			if entry.sensors, err = getSensors(i.client, string(entry.links.Sensors)); err != nil {
+				return nil, fmt.Errorf("error collecting /Chassis/%s/Sensors: %s", chassis.ID, err)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// biosInventory fetches the BIOS of each system, read by the system and BIOS
+// collectors, once per scrape.
+type biosInventory struct {
+	mu      sync.Mutex
+	entries map[string]*biosEntry
+}
+
+type biosEntry struct {
+	once sync.Once
+	bios *redfish.Bios
+	err  error
+}
+
+// get returns the BIOS of system, fetching it on the first call.
+func (i *biosInventory) get(system *redfish.ComputerSystem) (*redfish.Bios, error) {
+	i.mu.Lock()
+	if i.entries == nil {
+		i.entries = make(map[string]*biosEntry)
+	}
+	entry, ok := i.entries[system.ODataID]
+	if !ok {
+		entry = &biosEntry{}
+		i.entries[system.ODataID] = entry
+	}
+	i.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.bios, entry.err = system.Bios()
+	})
+
+	return entry.bios, entry.err
+}
+
+// resource decodes the named resource of the chassis into v and reports
+// whether the chassis has it.
+func (e chassisEntry) resource(name string, v interface{}) (bool, error) {
+	b, ok := e.resources[name]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return true, fmt.Errorf("error collecting /Chassis/%s/%s: %s", e.chassis.ID, name, err)
+	}
+
+	return true, nil
+}
+
+// getRawResource returns the body of the resource at uri.
+func getRawResource(client common.Client, uri string) ([]byte, error) {
+	resp, err := client.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
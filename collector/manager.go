@@ -4,14 +4,91 @@ import (
 	"fmt"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
 	"net"
+	"path"
+	"strings"
+	"time"
 )
 
 type ManagerCollector struct {
 	client *gofish.APIClient
 }
 
+// managerResource holds the manager properties gofish does not expose, along
+// with the ones that have to be read at a known time.
+type managerResource struct {
+	DateTime              string
+	DateTimeLocalOffset   string
+	LastResetTime         string
+	ManagerDiagnosticData common.Link
+	NetworkProtocol       common.Link
+}
+
+type networkProtocolSetting struct {
+	NTPServers      []string
+	Port            *float64
+	ProtocolEnabled bool
+}
+
+type managerNetworkProtocol struct {
+	HTTP         *networkProtocolSetting
+	HTTPS        *networkProtocolSetting
+	IPMI         *networkProtocolSetting
+	KVMIP        *networkProtocolSetting
+	NTP          *networkProtocolSetting
+	RDP          *networkProtocolSetting
+	RFB          *networkProtocolSetting
+	SNMP         *networkProtocolSetting
+	SSDP         *networkProtocolSetting
+	SSH          *networkProtocolSetting
+	Telnet       *networkProtocolSetting
+	VirtualMedia *networkProtocolSetting
+}
+
+type managerDiagnosticData struct {
+	BootTimeStatistics struct {
+		FirmwareTimeSeconds  *float64
+		InitrdTimeSeconds    *float64
+		KernelTimeSeconds    *float64
+		LoaderTimeSeconds    *float64
+		UserSpaceTimeSeconds *float64
+	}
+	FreeStorageSpaceKiB *float64
+	I2CBuses            []struct {
+		BusErrorCount         *float64
+		I2CBusName            string
+		NACKCount             *float64
+		TotalTransactionCount *float64
+	}
+	MemoryStatistics struct {
+		AvailableBytes *float64
+		FreeBytes      *float64
+		TotalBytes     *float64
+		UsedBytes      *float64
+	}
+	ProcessorStatistics struct {
+		KernelPercent *float64
+		UserPercent   *float64
+	}
+	ServiceRootUptimeSeconds *float64
+	TopProcesses             []struct {
+		CommandLine        string
+		KernelTimeSeconds  *float64
+		ResidentSetSizeKiB *float64
+		RestartCount       *float64
+		Threads            *float64
+		UserTimeSeconds    *float64
+	}
+}
+
+type sessionService struct {
+	ServiceEnabled bool
+	SessionTimeout float64
+	Sessions       common.Link
+}
+
 func (c *ManagerCollector) Collect(ch chan<- prometheus.Metric) error {
 	managers, err := c.client.Service.Managers()
 	if err != nil {
@@ -29,6 +106,87 @@ func (c *ManagerCollector) Collect(ch chan<- prometheus.Metric) error {
 		for _, intf := range ethernetInterfaces {
 			c.processEthernetInterface(ch, intf, manager.ID)
 		}
+
+		virtualMedia, err := manager.VirtualMedia()
+		if err != nil {
+			return fmt.Errorf("error collecting /Managers/%s/VirtualMedia: %s", manager.ID, err)
+		}
+
+		for _, media := range virtualMedia {
+			c.processVirtualMedia(ch, media, manager.ID)
+		}
+
+		hostInterfaces, err := manager.HostInterfaces()
+		if err != nil {
+			return fmt.Errorf("error collecting /Managers/%s/HostInterfaces: %s", manager.ID, err)
+		}
+
+		for _, intf := range hostInterfaces {
+			c.processHostInterface(ch, intf, manager.ID)
+		}
+
+		var resource managerResource
+		start := time.Now()
+		if err := getResource(c.client, manager.ODataID, &resource); err != nil {
+			return fmt.Errorf("error collecting /Managers/%s: %s", manager.ID, err)
+		}
+		// assume the BMC read its clock halfway through the request
+		c.processClock(ch, resource, start.Add(time.Since(start)/2), manager.ID)
+
+		if resource.ManagerDiagnosticData != "" {
+			var diagnostics managerDiagnosticData
+			if err := getResource(c.client, string(resource.ManagerDiagnosticData), &diagnostics); err != nil {
+				return fmt.Errorf("error collecting /Managers/%s/ManagerDiagnosticData: %s", manager.ID, err)
+			}
+			c.processDiagnosticData(ch, diagnostics, manager.ID)
+		}
+
+		if resource.NetworkProtocol != "" {
+			var protocol managerNetworkProtocol
+			if err := getResource(c.client, string(resource.NetworkProtocol), &protocol); err != nil {
+				return fmt.Errorf("error collecting /Managers/%s/NetworkProtocol: %s", manager.ID, err)
+			}
+			c.processNetworkProtocol(ch, protocol, manager.ID)
+		}
+	}
+
+	var root serviceRootLinks
+	if err := getResource(c.client, common.DefaultServiceRoot, &root); err != nil {
+		return fmt.Errorf("error collecting service root: %s", err)
+	}
+
+	if root.AccountService != "" {
+		accountService, err := c.client.Service.AccountService()
+		if err != nil {
+			return fmt.Errorf("error collecting /AccountService: %s", err)
+		}
+		c.processAccountService(ch, accountService)
+
+		accounts, err := accountService.Accounts()
+		if err != nil {
+			return fmt.Errorf("error collecting /AccountService/Accounts: %s", err)
+		}
+
+		for _, account := range accounts {
+			c.processAccount(ch, account)
+		}
+	}
+
+	if root.SessionService != "" {
+		var service sessionService
+		if err := getResource(c.client, string(root.SessionService), &service); err != nil {
+			return fmt.Errorf("error collecting /SessionService: %s", err)
+		}
+
+		// count the members rather than reading every session, which may not
+		// be readable for sessions of other users
+		var sessions []string
+		if service.Sessions != "" {
+			if sessions, err = getMembers(c.client, string(service.Sessions)); err != nil {
+				return fmt.Errorf("error collecting /SessionService/Sessions: %s", err)
+			}
+		}
+		c.processSessionService(ch, service, len(sessions))
 	}
 
 	return nil
@@ -58,10 +216,26 @@ func (c *ManagerCollector) processManager(ch chan<- prometheus.Metric, manager *
 		nil, constLabels,
 	)
 
+	consoleSessionsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "console_sessions_max"),
+		"Maximum concurrent console sessions",
+		[]string{"console"}, constLabels,
+	)
+
 	ch <- prometheus.MustNewConstMetric(commandShellDesc, prometheus.GaugeValue, btof(manager.CommandShell.ServiceEnabled))
 	ch <- prometheus.MustNewConstMetric(graphicalConsoleDesc, prometheus.GaugeValue, btof(manager.GraphicalConsole.ServiceEnabled))
 	ch <- prometheus.MustNewConstMetric(serialConsoleDesc, prometheus.GaugeValue, btof(manager.SerialConsole.ServiceEnabled))
 
+	if v := manager.CommandShell.MaxConcurrentSessions; v > 0 {
+		ch <- prometheus.MustNewConstMetric(consoleSessionsDesc, prometheus.GaugeValue, float64(v), "command_shell")
+	}
+	if v := manager.GraphicalConsole.MaxConcurrentSessions; v > 0 {
+		ch <- prometheus.MustNewConstMetric(consoleSessionsDesc, prometheus.GaugeValue, float64(v), "graphical")
+	}
+	if v := manager.SerialConsole.MaxConcurrentSessions; v > 0 {
+		ch <- prometheus.MustNewConstMetric(consoleSessionsDesc, prometheus.GaugeValue, float64(v), "serial")
+	}
+
 	healthDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "manager", "health"),
 		"Manager health; 0: OK, 1: Warning, 2: Critical",
@@ -100,6 +274,11 @@ func (c *ManagerCollector) processEthernetInterface(ch chan<- prometheus.Metric,
 		"Ethernet interface speed, bytes/s",
 		[]string{"duplex"}, constLabels,
 	)
+	addressDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "ethernet_interface_address_info"),
+		"Ethernet interface IP address configuration",
+		[]string{"address_family", "ip_address", "prefix_length", "origin", "gateway", "vlan_id", "hostname", "fqdn"}, constLabels,
+	)
 
 	healthDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "system", "ethernet_interface_health"),
@@ -115,6 +294,10 @@ func (c *ManagerCollector) processEthernetInterface(ch chan<- prometheus.Metric,
 	ch <- prometheus.MustNewConstMetric(enabledDesc, prometheus.GaugeValue, btof(intf.InterfaceEnabled))
 	ch <- prometheus.MustNewConstMetric(speedDesc, prometheus.GaugeValue, float64(intf.SpeedMbps)*mebi/8, map[bool]string{true: "full", false: "half"}[intf.FullDuplex])
 
+	for _, a := range interfaceAddresses(intf) {
+		ch <- prometheus.MustNewConstMetric(addressDesc, prometheus.GaugeValue, 1, a.family, a.address, a.prefixLength, a.origin, a.gateway, interfaceVLAN(intf), intf.HostName, intf.FQDN)
+	}
+
 	if e := enumHealth(intf.Status.Health); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
 	}
@@ -122,3 +305,462 @@ func (c *ManagerCollector) processEthernetInterface(ch chan<- prometheus.Metric,
 		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
 	}
 }
+
+func (c *ManagerCollector) processVirtualMedia(ch chan<- prometheus.Metric, media *redfish.VirtualMedia, managerID string) {
+	constLabels := prometheus.Labels{"id": media.ID, "name": media.Name, "manager_id": managerID}
+
+	insertedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "virtual_media_inserted"),
+		"Virtual media inserted; 0: No, 1: Yes",
+		[]string{"image_name", "connected_via"}, constLabels,
+	)
+	writeProtectedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "virtual_media_write_protected"),
+		"Virtual media write protected; 0: No, 1: Yes",
+		nil, constLabels,
+	)
+
+	ch <- prometheus.MustNewConstMetric(insertedDesc, prometheus.GaugeValue, btof(media.Inserted), media.ImageName, string(media.ConnectedVia))
+
+	if media.Inserted {
+		ch <- prometheus.MustNewConstMetric(writeProtectedDesc, prometheus.GaugeValue, btof(media.WriteProtected))
+	}
+}
+
+func (c *ManagerCollector) processHostInterface(ch chan<- prometheus.Metric, intf *redfish.HostInterface, managerID string) {
+	constLabels := prometheus.Labels{"id": intf.ID, "name": intf.Name, "manager_id": managerID, "interface_type": string(intf.HostInterfaceType)}
+
+	enabledDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "host_interface_status"),
+		"Host interface status; 0: Disabled, 1: Enabled",
+		nil, constLabels,
+	)
+	authenticationModeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "host_interface_authentication_mode_info"),
+		"Authentication mode allowed on the host interface",
+		[]string{"mode"}, constLabels,
+	)
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "host_interface_health"),
+		"Host interface health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "host_interface_state"),
+		"Host interface state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	ch <- prometheus.MustNewConstMetric(enabledDesc, prometheus.GaugeValue, btof(intf.InterfaceEnabled))
+
+	for _, mode := range intf.AuthenticationModes {
+		ch <- prometheus.MustNewConstMetric(authenticationModeDesc, prometheus.GaugeValue, 1, string(mode))
+	}
+
+	if e := enumHealth(intf.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(intf.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
+}
+
+func (c *ManagerCollector) processClock(ch chan<- prometheus.Metric, resource managerResource, requestTime time.Time, managerID string) {
+	constLabels := prometheus.Labels{"manager_id": managerID}
+
+	driftDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "clock_drift_seconds"),
+		"Manager clock offset from the exporter clock, s",
+		nil, constLabels,
+	)
+	lastResetDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "last_reset_timestamp_seconds"),
+		"Time of the last manager reset, seconds since epoch",
+		nil, constLabels,
+	)
+	localOffsetDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "clock_local_offset_seconds"),
+		"Manager local time offset from UTC, s",
+		nil, constLabels,
+	)
+
+	if t, ok := parseTime(resource.LastResetTime); ok {
+		ch <- prometheus.MustNewConstMetric(lastResetDesc, prometheus.GaugeValue, t)
+	}
+
+	offset, offsetErr := time.Parse("-07:00", resource.DateTimeLocalOffset)
+	if offsetErr == nil {
+		_, seconds := offset.Zone()
+		ch <- prometheus.MustNewConstMetric(localOffsetDesc, prometheus.GaugeValue, float64(seconds))
+	}
+
+	t, err := time.Parse(time.RFC3339, resource.DateTime)
+	if err != nil && offsetErr == nil {
+		// some implementations leave the offset out of DateTime
+		t, err = time.Parse(time.RFC3339, resource.DateTime+resource.DateTimeLocalOffset)
+	}
+	if err == nil {
+		ch <- prometheus.MustNewConstMetric(driftDesc, prometheus.GaugeValue, t.Sub(requestTime).Seconds())
+	}
+}
+
+func (c *ManagerCollector) processDiagnosticData(ch chan<- prometheus.Metric, diagnostics managerDiagnosticData, managerID string) {
+	constLabels := prometheus.Labels{"manager_id": managerID}
+
+	cpuDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "cpu_utilization_ratio"),
+		"Manager CPU utilization, %",
+		[]string{"mode"}, constLabels,
+	)
+	memoryTotalDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "memory_total_bytes"),
+		"Manager total memory, bytes",
+		nil, constLabels,
+	)
+	memoryUsedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "memory_used_bytes"),
+		"Manager used memory, bytes",
+		nil, constLabels,
+	)
+	memoryFreeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "memory_free_bytes"),
+		"Manager free memory, bytes",
+		nil, constLabels,
+	)
+	memoryAvailableDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "memory_available_bytes"),
+		"Manager memory available for new processes, bytes",
+		nil, constLabels,
+	)
+	storageFreeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "storage_free_bytes"),
+		"Manager free storage space, bytes",
+		nil, constLabels,
+	)
+	uptimeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "service_uptime_seconds"),
+		"Redfish service uptime, s",
+		nil, constLabels,
+	)
+	bootTimeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "boot_time_seconds"),
+		"Time spent in a manager boot stage, s",
+		[]string{"stage"}, constLabels,
+	)
+	processMemoryDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "process_resident_memory_bytes"),
+		"Manager process resident memory, summed per executable, bytes",
+		[]string{"process"}, constLabels,
+	)
+	processCPUDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "process_cpu_seconds_total"),
+		"Manager process CPU time, summed per executable, s",
+		[]string{"process", "mode"}, constLabels,
+	)
+	processThreadsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "process_threads"),
+		"Manager process threads, summed per executable",
+		[]string{"process"}, constLabels,
+	)
+	processRestartsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "process_restarts_total"),
+		"Manager process restarts, summed per executable",
+		[]string{"process"}, constLabels,
+	)
+	i2cErrorsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "i2c_bus_errors_total"),
+		"Manager I2C bus errors",
+		[]string{"bus"}, constLabels,
+	)
+	i2cNACKsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "i2c_bus_nacks_total"),
+		"Manager I2C bus NACKs",
+		[]string{"bus"}, constLabels,
+	)
+	i2cTransactionsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "i2c_bus_transactions_total"),
+		"Manager I2C bus transactions",
+		[]string{"bus"}, constLabels,
+	)
+
+	if v := diagnostics.ProcessorStatistics.KernelPercent; v != nil {
+		ch <- prometheus.MustNewConstMetric(cpuDesc, prometheus.GaugeValue, *v/100, "kernel")
+	}
+	if v := diagnostics.ProcessorStatistics.UserPercent; v != nil {
+		ch <- prometheus.MustNewConstMetric(cpuDesc, prometheus.GaugeValue, *v/100, "user")
+	}
+
+	memory := diagnostics.MemoryStatistics
+	for _, m := range []struct {
+		desc  *prometheus.Desc
+		value *float64
+	}{
+		{memoryTotalDesc, memory.TotalBytes},
+		{memoryUsedDesc, memory.UsedBytes},
+		{memoryFreeDesc, memory.FreeBytes},
+		{memoryAvailableDesc, memory.AvailableBytes},
+	} {
+		if m.value != nil {
+			ch <- prometheus.MustNewConstMetric(m.desc, prometheus.GaugeValue, *m.value)
+		}
+	}
+
+	if v := diagnostics.FreeStorageSpaceKiB; v != nil {
+		ch <- prometheus.MustNewConstMetric(storageFreeDesc, prometheus.GaugeValue, *v*kibi)
+	}
+	if v := diagnostics.ServiceRootUptimeSeconds; v != nil {
+		ch <- prometheus.MustNewConstMetric(uptimeDesc, prometheus.GaugeValue, *v)
+	}
+
+	boot := diagnostics.BootTimeStatistics
+	for _, stage := range []struct {
+		name  string
+		value *float64
+	}{
+		{"firmware", boot.FirmwareTimeSeconds},
+		{"loader", boot.LoaderTimeSeconds},
+		{"kernel", boot.KernelTimeSeconds},
+		{"initrd", boot.InitrdTimeSeconds},
+		{"user_space", boot.UserSpaceTimeSeconds},
+	} {
+		if stage.value != nil {
+			ch <- prometheus.MustNewConstMetric(bootTimeDesc, prometheus.GaugeValue, *stage.value, stage.name)
+		}
+	}
+
+	// processes are summed per executable, as several may share a command line
+	// and full command lines make for unbounded label values
+	type processTotals struct {
+		memory, kernelTime, userTime, threads, restarts *float64
+	}
+	add := func(total **float64, v float64) {
+		if *total == nil {
+			*total = new(float64)
+		}
+		**total += v
+	}
+
+	totals := make(map[string]*processTotals)
+	for _, process := range diagnostics.TopProcesses {
+		name := processName(process.CommandLine)
+		t, ok := totals[name]
+		if !ok {
+			t = &processTotals{}
+			totals[name] = t
+		}
+
+		if v := process.ResidentSetSizeKiB; v != nil {
+			add(&t.memory, *v*kibi)
+		}
+		if v := process.KernelTimeSeconds; v != nil {
+			add(&t.kernelTime, *v)
+		}
+		if v := process.UserTimeSeconds; v != nil {
+			add(&t.userTime, *v)
+		}
+		if v := process.Threads; v != nil {
+			add(&t.threads, *v)
+		}
+		if v := process.RestartCount; v != nil {
+			add(&t.restarts, *v)
+		}
+	}
+
+	for name, t := range totals {
+		if t.memory != nil {
+			ch <- prometheus.MustNewConstMetric(processMemoryDesc, prometheus.GaugeValue, *t.memory, name)
+		}
+		if t.kernelTime != nil {
+			ch <- prometheus.MustNewConstMetric(processCPUDesc, prometheus.CounterValue, *t.kernelTime, name, "kernel")
+		}
+		if t.userTime != nil {
+			ch <- prometheus.MustNewConstMetric(processCPUDesc, prometheus.CounterValue, *t.userTime, name, "user")
+		}
+		if t.threads != nil {
+			ch <- prometheus.MustNewConstMetric(processThreadsDesc, prometheus.GaugeValue, *t.threads, name)
+		}
+		if t.restarts != nil {
+			ch <- prometheus.MustNewConstMetric(processRestartsDesc, prometheus.CounterValue, *t.restarts, name)
+		}
+	}
+
+	for _, bus := range diagnostics.I2CBuses {
+		if v := bus.BusErrorCount; v != nil {
+			ch <- prometheus.MustNewConstMetric(i2cErrorsDesc, prometheus.CounterValue, *v, bus.I2CBusName)
+		}
+		if v := bus.NACKCount; v != nil {
+			ch <- prometheus.MustNewConstMetric(i2cNACKsDesc, prometheus.CounterValue, *v, bus.I2CBusName)
+		}
+		if v := bus.TotalTransactionCount; v != nil {
+			ch <- prometheus.MustNewConstMetric(i2cTransactionsDesc, prometheus.CounterValue, *v, bus.I2CBusName)
+		}
+	}
+}
+
+// processName returns the executable name of a process command line, e.g.
+// bmcweb for "/usr/bin/bmcweb -v", or kworker for "[kworker/0:1H]".
+func processName(commandLine string) string {
+	fields := strings.Fields(commandLine)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	if name := fields[0]; strings.HasPrefix(name, "[") {
+		name = strings.Trim(name, "[]")
+		return strings.SplitN(name, "/", 2)[0]
+	}
+
+	return path.Base(fields[0])
+}
+
+func (c *ManagerCollector) processNetworkProtocol(ch chan<- prometheus.Metric, protocol managerNetworkProtocol, managerID string) {
+	constLabels := prometheus.Labels{"manager_id": managerID}
+
+	enabledDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "network_protocol_enabled"),
+		"Manager network protocol status; 0: Disabled, 1: Enabled",
+		[]string{"protocol"}, constLabels,
+	)
+	portDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "network_protocol_port"),
+		"Manager network protocol port",
+		[]string{"protocol"}, constLabels,
+	)
+	ntpServersDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "ntp_servers"),
+		"Configured NTP servers",
+		nil, constLabels,
+	)
+	ntpServerDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "manager", "ntp_server_info"),
+		"Configured NTP server",
+		[]string{"server"}, constLabels,
+	)
+
+	for _, p := range []struct {
+		name    string
+		setting *networkProtocolSetting
+	}{
+		{"HTTP", protocol.HTTP},
+		{"HTTPS", protocol.HTTPS},
+		{"IPMI", protocol.IPMI},
+		{"KVMIP", protocol.KVMIP},
+		{"NTP", protocol.NTP},
+		{"RDP", protocol.RDP},
+		{"RFB", protocol.RFB},
+		{"SNMP", protocol.SNMP},
+		{"SSDP", protocol.SSDP},
+		{"SSH", protocol.SSH},
+		{"Telnet", protocol.Telnet},
+		{"VirtualMedia", protocol.VirtualMedia},
+	} {
+		if p.setting == nil {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(enabledDesc, prometheus.GaugeValue, btof(p.setting.ProtocolEnabled), p.name)
+		if p.setting.Port != nil {
+			ch <- prometheus.MustNewConstMetric(portDesc, prometheus.GaugeValue, *p.setting.Port, p.name)
+		}
+	}
+
+	if protocol.NTP != nil {
+		var servers []string
+		seen := make(map[string]bool)
+		for _, server := range protocol.NTP.NTPServers {
+			// unused server slots are reported as empty strings or null, and
+			// some services repeat entries
+			if server = strings.TrimSpace(server); server != "" && !seen[server] {
+				seen[server] = true
+				servers = append(servers, server)
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(ntpServersDesc, prometheus.GaugeValue, float64(len(servers)))
+		for _, server := range servers {
+			ch <- prometheus.MustNewConstMetric(ntpServerDesc, prometheus.GaugeValue, 1, server)
+		}
+	}
+}
+
+func (c *ManagerCollector) processAccountService(ch chan<- prometheus.Metric, service *redfish.AccountService) {
+	minPasswordLengthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "account_service", "password_length_min"),
+		"Minimum password length",
+		nil, nil,
+	)
+	maxPasswordLengthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "account_service", "password_length_max"),
+		"Maximum password length",
+		nil, nil,
+	)
+	lockoutThresholdDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "account_service", "lockout_threshold"),
+		"Failed login attempts before an account is locked out; 0: Never",
+		nil, nil,
+	)
+	lockoutDurationDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "account_service", "lockout_duration_seconds"),
+		"Account lockout duration, s; 0: Until unlocked by an administrator",
+		nil, nil,
+	)
+
+	serviceEnabledDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "account_service", "enabled"),
+		"Account service status; 0: Disabled, 1: Enabled",
+		nil, nil,
+	)
+
+	ch <- prometheus.MustNewConstMetric(minPasswordLengthDesc, prometheus.GaugeValue, float64(service.MinPasswordLength))
+	ch <- prometheus.MustNewConstMetric(maxPasswordLengthDesc, prometheus.GaugeValue, float64(service.MaxPasswordLength))
+	ch <- prometheus.MustNewConstMetric(lockoutThresholdDesc, prometheus.GaugeValue, float64(service.AccountLockoutThreshold))
+	ch <- prometheus.MustNewConstMetric(lockoutDurationDesc, prometheus.GaugeValue, float64(service.AccountLockoutDuration))
+	ch <- prometheus.MustNewConstMetric(serviceEnabledDesc, prometheus.GaugeValue, btof(service.ServiceEnabled))
+}
+
+func (c *ManagerCollector) processAccount(ch chan<- prometheus.Metric, account *redfish.ManagerAccount) {
+	// skip the empty slots some BMCs (e.g. iDRAC) report as accounts
+	if account.UserName == "" {
+		return
+	}
+
+	constLabels := prometheus.Labels{"id": account.ID, "username": account.UserName, "role": account.RoleID}
+
+	enabledDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "account_service", "account_enabled"),
+		"Account status; 0: Disabled, 1: Enabled",
+		nil, constLabels,
+	)
+	lockedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "account_service", "account_locked"),
+		"Account lockout status; 0: Unlocked, 1: Locked",
+		nil, constLabels,
+	)
+
+	ch <- prometheus.MustNewConstMetric(enabledDesc, prometheus.GaugeValue, btof(account.Enabled))
+	ch <- prometheus.MustNewConstMetric(lockedDesc, prometheus.GaugeValue, btof(account.Locked))
+}
+
+func (c *ManagerCollector) processSessionService(ch chan<- prometheus.Metric, service sessionService, sessions int) {
+	sessionsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "session_service", "sessions"),
+		"Active Redfish sessions, including the exporter's own",
+		nil, nil,
+	)
+	timeoutDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "session_service", "timeout_seconds"),
+		"Idle session timeout, s",
+		nil, nil,
+	)
+
+	serviceEnabledDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "session_service", "enabled"),
+		"Session service status; 0: Disabled, 1: Enabled",
+		nil, nil,
+	)
+
+	ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(sessions))
+	ch <- prometheus.MustNewConstMetric(timeoutDesc, prometheus.GaugeValue, service.SessionTimeout)
+	ch <- prometheus.MustNewConstMetric(serviceEnabledDesc, prometheus.GaugeValue, btof(service.ServiceEnabled))
+}
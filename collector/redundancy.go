@@ -0,0 +1,245 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"strconv"
+	"strings"
+)
+
+type RedundancyCollector struct {
+	client    *gofish.APIClient
+	inventory *chassisInventory
+}
+
+// redundancy is a Redundancy object, as embedded in Power, Thermal and
+// Manager resources.
+type redundancy struct {
+	MemberID        string `json:"MemberId"`
+	Name            string
+	MaxNumSupported int
+	MinNumNeeded    int
+	Mode            string
+	RedundancySet   common.Links
+	Status          common.Status
+}
+
+// redundantGroup is a RedundantGroup object, as embedded in PowerSubsystem
+// and ThermalSubsystem resources.
+type redundantGroup struct {
+	MaxSupportedInGroup int
+	MinNeededInGroup    int
+	RedundancyGroup     common.Links
+	RedundancyType      string
+	Status              common.Status
+}
+
+type redundancyMember struct {
+	ODataID string `json:"@odata.id"`
+	Status  common.Status
+}
+
+// redundancyMembers are the members embedded in a resource. Links to member
+// collections, as found in subsystem resources, are ignored.
+type redundancyMembers []redundancyMember
+
+func (m *redundancyMembers) UnmarshalJSON(b []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		return nil
+	}
+
+	return json.Unmarshal(b, (*[]redundancyMember)(m))
+}
+
+// redundantResource holds the redundancy sets of a resource, along with the
+// members embedded in it.
+type redundantResource struct {
+	Redundancy            []redundancy
+	FanRedundancy         []redundantGroup
+	PowerSupplyRedundancy []redundantGroup
+	Fans                  redundancyMembers
+	PowerSupplies         redundancyMembers
+}
+
+func (c *RedundancyCollector) Collect(ch chan<- prometheus.Metric) error {
+	entries, err := c.inventory.get()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		chassis := entry.chassis
+
+		for _, name := range []string{"Power", "PowerSubsystem", "Thermal", "ThermalSubsystem"} {
+			var resource redundantResource
+			if ok, err := entry.resource(name, &resource); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+
+			constLabels := prometheus.Labels{"chassis_id": chassis.ID, "resource": name}
+			if err := c.processRedundantResource(ch, resource, "chassis", constLabels); err != nil {
+				return fmt.Errorf("error collecting /Chassis/%s/%s redundancy: %s", chassis.ID, name, err)
+			}
+		}
+	}
+
+	managers, err := c.client.Service.Managers()
+	if err != nil {
+		return fmt.Errorf("error collecting /Managers: %s", err)
+	}
+
+	for _, manager := range managers {
+		var resource redundantResource
+		if err := getResource(c.client, manager.ODataID, &resource); err != nil {
+			return fmt.Errorf("error collecting /Managers/%s: %s", manager.ID, err)
+		}
+
+		constLabels := prometheus.Labels{"manager_id": manager.ID, "resource": "Manager"}
+		if err := c.processRedundantResource(ch, resource, "manager", constLabels); err != nil {
+			return fmt.Errorf("error collecting /Managers/%s redundancy: %s", manager.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *RedundancyCollector) processRedundantResource(ch chan<- prometheus.Metric, resource redundantResource, subsystem string, constLabels prometheus.Labels) error {
+	known := make(map[string]common.Status)
+	for _, member := range append(resource.Fans, resource.PowerSupplies...) {
+		known[member.ODataID] = member.Status
+	}
+
+	sets := resource.Redundancy
+	groups := map[string][]redundantGroup{
+		"FanRedundancy":         resource.FanRedundancy,
+		"PowerSupplyRedundancy": resource.PowerSupplyRedundancy,
+	}
+	for name, group := range groups {
+		for i, g := range group {
+			sets = append(sets, redundancy{
+				MemberID:        strconv.Itoa(i),
+				Name:            name,
+				MaxNumSupported: g.MaxSupportedInGroup,
+				MinNumNeeded:    g.MinNeededInGroup,
+				Mode:            g.RedundancyType,
+				RedundancySet:   g.RedundancyGroup,
+				Status:          g.Status,
+			})
+		}
+	}
+
+	for _, set := range sets {
+		healthy, err := c.healthyMembers(set.RedundancySet, known)
+		if err != nil {
+			return err
+		}
+		c.processRedundancy(ch, set, healthy, subsystem, constLabels)
+	}
+
+	return nil
+}
+
+// healthyMembers counts the members of a redundancy set reporting OK health.
+// Members embedded in the owning resource are referenced by a JSON pointer
+// fragment and looked up in known, others are fetched.
+func (c *RedundancyCollector) healthyMembers(set common.Links, known map[string]common.Status) (int, error) {
+	var healthy int
+	for _, link := range set.ToStrings() {
+		status, ok := known[link]
+		if !ok && !strings.Contains(link, "#") {
+			var member redundancyMember
+			if err := getResource(c.client, link, &member); err != nil {
+				return 0, fmt.Errorf("error collecting %s: %s", link, err)
+			}
+			status = member.Status
+		}
+
+		if status.Health == common.OKHealth {
+			healthy++
+		}
+	}
+
+	return healthy, nil
+}
+
+func (c *RedundancyCollector) processRedundancy(ch chan<- prometheus.Metric, set redundancy, healthy int, subsystem string, labels prometheus.Labels) {
+	constLabels := prometheus.Labels{"id": set.MemberID, "name": set.Name}
+	for k, v := range labels {
+		constLabels[k] = v
+	}
+
+	modeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "redundancy_mode"),
+		"Redundancy mode; 0: Failover, 1: N+m, 2: Sharing, 3: Sparing, 4: NotRedundant",
+		nil, constLabels,
+	)
+	minMembersDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "redundancy_members_min"),
+		"Minimum number of members needed for redundancy",
+		nil, constLabels,
+	)
+	maxMembersDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "redundancy_members_max"),
+		"Maximum number of members supported in the redundancy set",
+		nil, constLabels,
+	)
+	membersDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "redundancy_members"),
+		"Members in the redundancy set",
+		nil, constLabels,
+	)
+	healthyMembersDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "redundancy_members_healthy"),
+		"Members in the redundancy set reporting OK health",
+		nil, constLabels,
+	)
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "redundancy_health"),
+		"Redundancy health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "redundancy_state"),
+		"Redundancy state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	if e := enumRedundancyMode(set.Mode); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(modeDesc, prometheus.GaugeValue, e)
+	}
+	ch <- prometheus.MustNewConstMetric(minMembersDesc, prometheus.GaugeValue, float64(set.MinNumNeeded))
+	ch <- prometheus.MustNewConstMetric(maxMembersDesc, prometheus.GaugeValue, float64(set.MaxNumSupported))
+	ch <- prometheus.MustNewConstMetric(membersDesc, prometheus.GaugeValue, float64(len(set.RedundancySet)))
+	ch <- prometheus.MustNewConstMetric(healthyMembersDesc, prometheus.GaugeValue, float64(healthy))
+
+	if e := enumHealth(set.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(set.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
+}
+
+func enumRedundancyMode(e string) float64 {
+	switch e {
+	case "Failover":
+		return 0
+	case "N+m", "NPlusM":
+		return 1
+	case "Sharing":
+		return 2
+	case "Sparing":
+		return 3
+	case "NotRedundant":
+		return 4
+	default:
+		return -1
+	}
+}
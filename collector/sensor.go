@@ -0,0 +1,412 @@
+package collector
+
+import (
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+	"math"
+	"strings"
+)
+
+type SensorCollector struct {
+	client    *gofish.APIClient
+	inventory *chassisInventory
+}
+
+// sensorExcerpt is the reading of a Sensor as embedded in the resource it
+// measures.
+type sensorExcerpt struct {
+	Reading *float64
+}
+
+type sensorThreshold struct {
+	Reading *float64
+}
+
+type sensorThresholds struct {
+	LowerCaution  *sensorThreshold
+	LowerCritical *sensorThreshold
+	LowerFatal    *sensorThreshold
+	UpperCaution  *sensorThreshold
+	UpperCritical *sensorThreshold
+	UpperFatal    *sensorThreshold
+}
+
+type thresholdReading struct {
+	threshold string
+	direction string
+	reading   float64
+}
+
+// readings returns the reported thresholds along with their threshold and
+// direction label values.
+func (t sensorThresholds) readings() []thresholdReading {
+	var result []thresholdReading
+	for _, r := range []struct {
+		threshold *sensorThreshold
+		name      string
+		direction string
+	}{
+		{t.LowerCaution, "caution", "lower"},
+		{t.LowerCritical, "critical", "lower"},
+		{t.LowerFatal, "fatal", "lower"},
+		{t.UpperCaution, "caution", "upper"},
+		{t.UpperCritical, "critical", "upper"},
+		{t.UpperFatal, "fatal", "upper"},
+	} {
+		if r.threshold != nil && r.threshold.Reading != nil {
+			result = append(result, thresholdReading{r.name, r.direction, *r.threshold.Reading})
+		}
+	}
+
+	return result
+}
+
+// breached returns the most severe threshold crossed by reading; 0: None,
+// 1: Caution, 2: Critical, 3: Fatal.
+func (t sensorThresholds) breached(reading float64) float64 {
+	var breached float64
+	for _, r := range t.readings() {
+		if (r.direction == "upper" && reading >= r.reading) || (r.direction == "lower" && reading <= r.reading) {
+			breached = math.Max(breached, enumThreshold(r.threshold))
+		}
+	}
+
+	return breached
+}
+
+// sensor is a Redfish Sensor resource (Chassis/{id}/Sensors/{id}).
+type sensor struct {
+	ODataID         string `json:"@odata.id"`
+	ID              string `json:"Id"`
+	Name            string
+	PhysicalContext string
+	Reading         *float64
+	ReadingType     string
+	ReadingUnits    string
+	Status          common.Status
+	Thresholds      sensorThresholds
+}
+
+// getSensors returns the sensors in the collection at uri. Where the service
+// supports $expand the collection is read in one request, as it may hold
+// hundreds of sensors.
+func getSensors(client *gofish.APIClient, uri string) ([]sensor, error) {
+	expand := client.Service.ProtocolFeaturesSupported.ExpandQuery
+	if expand.NoLinks || expand.ExpandAll {
+		query := "*"
+		if expand.NoLinks {
+			query = "."
+		}
+		if expand.Levels {
+			query += "($levels=1)"
+		}
+
+		var collection struct {
+			Members []sensor
+		}
+		if err := getResource(client, uri+"?$expand="+query, &collection); err != nil {
+			return nil, err
+		}
+
+		expanded := true
+		for _, s := range collection.Members {
+			expanded = expanded && s.ID != ""
+		}
+		if expanded {
+			return collection.Members, nil
+		}
+	}
+
+	members, err := getMembers(client, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	sensors := make([]sensor, len(members))
+	for i, member := range members {
+		if err := getResource(client, member, &sensors[i]); err != nil {
+			return nil, fmt.Errorf("error collecting %s: %s", member, err)
+		}
+	}
+
+	return sensors, nil
+}
+
+func (c *SensorCollector) Collect(ch chan<- prometheus.Metric) error {
+	entries, err := c.inventory.get()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		chassis, links := entry.chassis, entry.links
+
+		if links.Sensors != "" {
+			for _, s := range entry.sensors {
+				c.processSensor(ch, s, chassis.ID)
+			}
+
+			continue
+		}
+
+		var thermal redfish.Thermal
+		var thermalThresholds legacyResourceThresholds
+		if ok, err := entry.resource("Thermal", &thermal); err != nil {
+			return err
+		} else if ok {
+			if _, err := entry.resource("Thermal", &thermalThresholds); err != nil {
+				return err
+			}
+
+			for i, t := range thermal.Temperatures {
+				c.processSensor(ch, temperatureSensor(t, thresholdsAt(thermalThresholds.Temperatures, i)), chassis.ID)
+			}
+
+			for i, fan := range thermal.Fans {
+				c.processSensor(ch, fanSensor(fan, thresholdsAt(thermalThresholds.Fans, i)), chassis.ID)
+			}
+		}
+
+		var power redfish.Power
+		var powerThresholds legacyResourceThresholds
+		if ok, err := entry.resource("Power", &power); err != nil {
+			return err
+		} else if ok {
+			if _, err := entry.resource("Power", &powerThresholds); err != nil {
+				return err
+			}
+
+			for _, control := range power.PowerControl {
+				c.processSensor(ch, powerControlSensor(control), chassis.ID)
+			}
+
+			for i, voltage := range power.Voltages {
+				c.processSensor(ch, voltageSensor(voltage, thresholdsAt(powerThresholds.Voltages, i)), chassis.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *SensorCollector) processSensor(ch chan<- prometheus.Metric, s sensor, chassisID string) {
+	_, unit := normalizeReading(s.ReadingType, s.ReadingUnits, 0)
+
+	constLabels := prometheus.Labels{"id": s.ID, "name": s.Name, "chassis_id": chassisID, "reading_type": s.ReadingType, "unit": unit, "physical_context": s.PhysicalContext}
+
+	readingDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "sensor", "reading"),
+		"Sensor reading, normalized to the base unit of the reading type",
+		nil, constLabels,
+	)
+	thresholdDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "sensor", "threshold"),
+		"Sensor threshold, normalized to the base unit of the reading type",
+		[]string{"threshold", "direction"}, constLabels,
+	)
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "sensor", "health"),
+		"Sensor health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "sensor", "state"),
+		"Sensor state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	if s.Reading != nil {
+		v, _ := normalizeReading(s.ReadingType, s.ReadingUnits, *s.Reading)
+		ch <- prometheus.MustNewConstMetric(readingDesc, prometheus.GaugeValue, v)
+	}
+
+	for _, t := range s.Thresholds.readings() {
+		v, _ := normalizeReading(s.ReadingType, s.ReadingUnits, t.reading)
+		ch <- prometheus.MustNewConstMetric(thresholdDesc, prometheus.GaugeValue, v, t.threshold, t.direction)
+	}
+
+	if e := enumHealth(s.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(s.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
+}
+
+// normalizeReading converts a sensor reading to the base unit of its reading
+// type and returns it along with the unit name.
+func normalizeReading(readingType string, units string, v float64) (float64, string) {
+	switch readingType {
+	case "Temperature":
+		return v, "celsius"
+	case "Humidity", "Percent":
+		return v / 100, "ratio"
+	case "Power", "Heat":
+		if units == "kW" {
+			return v * kilo, "watts"
+		}
+		return v, "watts"
+	case "EnergyJoules":
+		return v, "joules"
+	case "EnergyWh":
+		return v * 3600, "joules"
+	case "EnergykWh":
+		return v * kilowattHour, "joules"
+	case "Voltage":
+		return v, "volts"
+	case "Current":
+		return v, "amperes"
+	case "Frequency":
+		return v, "hertz"
+	case "Pressure", "PressurePa":
+		return v, "pascals"
+	case "PressurekPa":
+		return v * kilo, "pascals"
+	case "Rotational":
+		return v, "rpm"
+	case "AirFlow":
+		return v, "cubic_feet_per_minute"
+	case "LiquidFlow":
+		return v, "liters_per_second"
+	case "LiquidFlowLPM":
+		return v / 60, "liters_per_second"
+	case "LiquidLevel":
+		return v, "liters"
+	case "Altitude":
+		return v, "meters"
+	default:
+		return v, strings.ToLower(units)
+	}
+}
+
+func enumThreshold(e string) float64 {
+	switch e {
+	case "caution":
+		return 1
+	case "critical":
+		return 2
+	case "fatal":
+		return 3
+	default:
+		return 0
+	}
+}
+
+// legacyThresholds holds the thresholds of a Thermal or Power resource
+// member, which gofish decodes as 0 when absent.
+type legacyThresholds struct {
+	LowerThresholdNonCritical *float64
+	LowerThresholdCritical    *float64
+	LowerThresholdFatal       *float64
+	UpperThresholdNonCritical *float64
+	UpperThresholdCritical    *float64
+	UpperThresholdFatal       *float64
+}
+
+func (t legacyThresholds) thresholds() sensorThresholds {
+	return sensorThresholds{
+		LowerCaution:  legacyThreshold(t.LowerThresholdNonCritical),
+		LowerCritical: legacyThreshold(t.LowerThresholdCritical),
+		LowerFatal:    legacyThreshold(t.LowerThresholdFatal),
+		UpperCaution:  legacyThreshold(t.UpperThresholdNonCritical),
+		UpperCritical: legacyThreshold(t.UpperThresholdCritical),
+		UpperFatal:    legacyThreshold(t.UpperThresholdFatal),
+	}
+}
+
+func legacyThreshold(v *float64) *sensorThreshold {
+	if v == nil {
+		return nil
+	}
+
+	return &sensorThreshold{Reading: v}
+}
+
+// legacyResourceThresholds holds the member thresholds of a Thermal or Power
+// resource, in the order of the members.
+type legacyResourceThresholds struct {
+	Fans         []legacyThresholds
+	Temperatures []legacyThresholds
+	Voltages     []legacyThresholds
+}
+
+// thresholdsAt returns the thresholds of the i-th member, if reported.
+func thresholdsAt(thresholds []legacyThresholds, i int) legacyThresholds {
+	if i < len(thresholds) {
+		return thresholds[i]
+	}
+
+	return legacyThresholds{}
+}
+
+func temperatureSensor(t redfish.Temperature, thresholds legacyThresholds) sensor {
+	reading := float64(t.ReadingCelsius)
+
+	return sensor{
+		ID:              t.MemberID,
+		Name:            t.Name,
+		PhysicalContext: t.PhysicalContext,
+		Reading:         &reading,
+		ReadingType:     "Temperature",
+		ReadingUnits:    "Cel",
+		Status:          t.Status,
+		Thresholds:      thresholds.thresholds(),
+	}
+}
+
+func fanSensor(fan redfish.Fan, thresholds legacyThresholds) sensor {
+	reading := float64(fan.Reading)
+
+	s := sensor{
+		ID:              fan.MemberID,
+		Name:            fan.Name,
+		PhysicalContext: fan.PhysicalContext,
+		Reading:         &reading,
+		Status:          fan.Status,
+		Thresholds:      thresholds.thresholds(),
+	}
+
+	switch fan.ReadingUnits {
+	case redfish.RPMReadingUnits:
+		s.ReadingType, s.ReadingUnits = "Rotational", "RPM"
+	case redfish.PercentReadingUnits:
+		s.ReadingType, s.ReadingUnits = "Percent", "%"
+	default:
+		s.Reading = nil
+	}
+
+	return s
+}
+
+func voltageSensor(voltage redfish.Voltage, thresholds legacyThresholds) sensor {
+	reading := float64(voltage.ReadingVolts)
+
+	return sensor{
+		ID:              voltage.MemberID,
+		Name:            voltage.Name,
+		PhysicalContext: voltage.PhysicalContext,
+		Reading:         &reading,
+		ReadingType:     "Voltage",
+		ReadingUnits:    "V",
+		Status:          voltage.Status,
+		Thresholds:      thresholds.thresholds(),
+	}
+}
+
+func powerControlSensor(control redfish.PowerControl) sensor {
+	reading := float64(control.PowerConsumedWatts)
+
+	return sensor{
+		ID:              control.MemberID,
+		Name:            control.Name,
+		PhysicalContext: string(control.PhysicalContext),
+		Reading:         &reading,
+		ReadingType:     "Power",
+		ReadingUnits:    "W",
+		Status:          control.Status,
+	}
+}
@@ -0,0 +1,219 @@
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stmcginnis/gofish/redfish"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// State holds per-target observations that outlive a single scrape, so that
+// counters can be derived from changes between scrapes. It is optionally
+// persisted to a file to survive exporter restarts.
+type State struct {
+	path    string
+	mu      sync.Mutex
+	targets map[string]*targetRecord
+}
+
+type targetRecord struct {
+	Systems map[string]*systemRecord
+	Chassis map[string]*chassisRecord
+}
+
+type systemRecord struct {
+	LastResetTime string
+	PowerState    redfish.PowerState
+	BootProgress  string
+	Resets        float64
+}
+
+type chassisRecord struct {
+	PowerState  redfish.PowerState
+	Transitions float64
+}
+
+// NewState returns a State persisted to path, loading any state previously
+// saved there. If path is empty, the state is kept in memory only.
+func NewState(path string) (*State, error) {
+	s := &State{path: path, targets: make(map[string]*targetRecord)}
+	if path == "" {
+		return s, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading state file: %s", err)
+	}
+
+	var targets map[string]*targetRecord
+	if err := json.Unmarshal(b, &targets); err != nil {
+		return nil, fmt.Errorf("error unmarshalling state file: %s", err)
+	}
+
+	// files written before keys were normalized may hold raw endpoints
+	for endpoint, t := range targets {
+		s.targets[targetKey(endpoint)] = t
+	}
+
+	return s, nil
+}
+
+// Save writes the state to its file, if it has one.
+func (s *State) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	b, err := json.Marshal(s.targets)
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("error marshalling state: %s", err)
+	}
+
+	// write to a temporary file first, so a crash never leaves a truncated state file
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("error writing state file: %s", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing state file: %s", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing state file: %s", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("error writing state file: %s", err)
+	}
+
+	return nil
+}
+
+func (s *State) target(endpoint string) *targetRecord {
+	key := targetKey(endpoint)
+	t, ok := s.targets[key]
+	if !ok {
+		t = &targetRecord{}
+		s.targets[key] = t
+	}
+	if t.Systems == nil {
+		t.Systems = make(map[string]*systemRecord)
+	}
+	if t.Chassis == nil {
+		t.Chassis = make(map[string]*chassisRecord)
+	}
+
+	return t
+}
+
+// targetKey returns the key the state of endpoint is kept under, so that a
+// target addressed with or without scheme, default port or trailing slash
+// shares its state.
+func targetKey(endpoint string) string {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return endpoint
+	}
+
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if port == "" || (u.Scheme == "https" && port == "443") || (u.Scheme == "http" && port == "80") {
+		return host
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
+// targetState is the State of a single target.
+type targetState struct {
+	state    *State
+	endpoint string
+}
+
+// observeSystem records the current reset time, power state and boot progress
+// of a system and returns the number of resets seen so far. A change of
+// LastResetTime counts as a reset; where the system does not report it, a
+// power-on or the boot progress falling back to a pre-OS stage does.
+func (t targetState) observeSystem(id string, lastResetTime string, powerState redfish.PowerState, bootProgress string) float64 {
+	t.state.mu.Lock()
+	defer t.state.mu.Unlock()
+
+	systems := t.state.target(t.endpoint).Systems
+	r, ok := systems[id]
+	if !ok {
+		systems[id] = &systemRecord{LastResetTime: lastResetTime, PowerState: settledPowerState(powerState, ""), BootProgress: bootProgress}
+		return 0
+	}
+
+	var reset bool
+	if lastResetTime != "" && r.LastResetTime != "" {
+		reset = lastResetTime != r.LastResetTime
+	} else {
+		poweredOn := r.PowerState == redfish.OffPowerState && settledPowerState(powerState, r.PowerState) == redfish.OnPowerState
+		// None is reported while powered off, which the power-on already accounts for;
+		// moving between OSRunning and OEM is not a restart
+		current := enumBootProgress(bootProgress)
+		preOS := current > 0 && current < enumBootProgress("OSRunning")
+		restarted := preOS && current < enumBootProgress(r.BootProgress)
+		reset = poweredOn || restarted
+	}
+	if reset {
+		r.Resets++
+	}
+
+	r.LastResetTime = lastResetTime
+	r.PowerState = settledPowerState(powerState, r.PowerState)
+	r.BootProgress = bootProgress
+
+	return r.Resets
+}
+
+// observeChassis records the current power state of a chassis and returns
+// the number of transitions between On and Off seen so far.
+func (t targetState) observeChassis(id string, powerState redfish.PowerState) float64 {
+	t.state.mu.Lock()
+	defer t.state.mu.Unlock()
+
+	chassis := t.state.target(t.endpoint).Chassis
+	r, ok := chassis[id]
+	if !ok {
+		chassis[id] = &chassisRecord{PowerState: settledPowerState(powerState, "")}
+		return 0
+	}
+
+	current := settledPowerState(powerState, r.PowerState)
+	if r.PowerState != "" && current != r.PowerState {
+		r.Transitions++
+	}
+	r.PowerState = current
+
+	return r.Transitions
+}
+
+// settledPowerState returns e if it is On or Off, and previous while the
+// power state is transitioning or unknown.
+func settledPowerState(e redfish.PowerState, previous redfish.PowerState) redfish.PowerState {
+	switch e {
+	case redfish.OnPowerState, redfish.OffPowerState:
+		return e
+	default:
+		return previous
+	}
+}
@@ -0,0 +1,214 @@
+package collector
+
+import (
+	"github.com/stmcginnis/gofish/redfish"
+	"testing"
+)
+
+type systemObservation struct {
+	lastResetTime string
+	powerState    redfish.PowerState
+	bootProgress  string
+}
+
+func TestObserveSystem(t *testing.T) {
+	tests := []struct {
+		name         string
+		observations []systemObservation
+		resets       []float64
+	}{
+		{
+			name: "first observation",
+			observations: []systemObservation{
+				{"2026-10-01T12:00:00Z", redfish.OnPowerState, "OSRunning"},
+			},
+			resets: []float64{0},
+		},
+		{
+			name: "last reset time unchanged",
+			observations: []systemObservation{
+				{"2026-10-01T12:00:00Z", redfish.OnPowerState, "OSRunning"},
+				{"2026-10-01T12:00:00Z", redfish.OnPowerState, "OSRunning"},
+			},
+			resets: []float64{0, 0},
+		},
+		{
+			name: "last reset time changed",
+			observations: []systemObservation{
+				{"2026-10-01T12:00:00Z", redfish.OnPowerState, "OSRunning"},
+				{"2026-10-02T12:00:00Z", redfish.OnPowerState, "OSRunning"},
+				{"2026-10-03T12:00:00Z", redfish.OnPowerState, "OSRunning"},
+			},
+			resets: []float64{0, 1, 2},
+		},
+		{
+			name: "last reset time takes precedence over power state",
+			observations: []systemObservation{
+				{"2026-10-01T12:00:00Z", redfish.OffPowerState, "None"},
+				{"2026-10-01T12:00:00Z", redfish.OnPowerState, "MemoryInitializationStarted"},
+			},
+			resets: []float64{0, 0},
+		},
+		{
+			name: "power on",
+			observations: []systemObservation{
+				{"", redfish.OffPowerState, "None"},
+				{"", redfish.OnPowerState, "OSRunning"},
+			},
+			resets: []float64{0, 1},
+		},
+		{
+			name: "power on seen through transition",
+			observations: []systemObservation{
+				{"", redfish.OffPowerState, "None"},
+				{"", redfish.PoweringOnPowerState, "None"},
+				{"", redfish.OnPowerState, "OSRunning"},
+			},
+			resets: []float64{0, 0, 1},
+		},
+		{
+			name: "power off",
+			observations: []systemObservation{
+				{"", redfish.OnPowerState, "OSRunning"},
+				{"", redfish.OffPowerState, "None"},
+			},
+			resets: []float64{0, 0},
+		},
+		{
+			name: "boot progress back to pre-OS stage",
+			observations: []systemObservation{
+				{"", redfish.OnPowerState, "OSRunning"},
+				{"", redfish.OnPowerState, "MemoryInitializationStarted"},
+				{"", redfish.OnPowerState, "OSBootStarted"},
+				{"", redfish.OnPowerState, "OSRunning"},
+			},
+			resets: []float64{0, 1, 1, 1},
+		},
+		{
+			name: "boot progress from OEM to OSRunning",
+			observations: []systemObservation{
+				{"", redfish.OnPowerState, "OEM"},
+				{"", redfish.OnPowerState, "OSRunning"},
+				{"", redfish.OnPowerState, "OEM"},
+			},
+			resets: []float64{0, 0, 0},
+		},
+		{
+			name: "boot progress from OEM to pre-OS stage",
+			observations: []systemObservation{
+				{"", redfish.OnPowerState, "OEM"},
+				{"", redfish.OnPowerState, "SetupEntered"},
+			},
+			resets: []float64{0, 1},
+		},
+		{
+			name: "boot progress None while powered off",
+			observations: []systemObservation{
+				{"", redfish.OnPowerState, "OSRunning"},
+				{"", redfish.OffPowerState, "None"},
+				{"", redfish.OnPowerState, "PrimaryProcessorInitializationStarted"},
+			},
+			resets: []float64{0, 0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, _ := NewState("")
+			target := targetState{state, "https://bmc.local"}
+
+			for i, o := range tt.observations {
+				if got := target.observeSystem("1", o.lastResetTime, o.powerState, o.bootProgress); got != tt.resets[i] {
+					t.Errorf("observation %d: got %v resets, want %v", i, got, tt.resets[i])
+				}
+			}
+		})
+	}
+}
+
+func TestObserveChassis(t *testing.T) {
+	tests := []struct {
+		name        string
+		powerStates []redfish.PowerState
+		transitions []float64
+	}{
+		{
+			name:        "first observation",
+			powerStates: []redfish.PowerState{redfish.OnPowerState},
+			transitions: []float64{0},
+		},
+		{
+			name:        "on and off",
+			powerStates: []redfish.PowerState{redfish.OnPowerState, redfish.OffPowerState, redfish.OnPowerState},
+			transitions: []float64{0, 1, 2},
+		},
+		{
+			name:        "transitioning states",
+			powerStates: []redfish.PowerState{redfish.OffPowerState, redfish.PoweringOnPowerState, redfish.OnPowerState, redfish.PoweringOffPowerState},
+			transitions: []float64{0, 0, 1, 1},
+		},
+		{
+			name:        "unknown first",
+			powerStates: []redfish.PowerState{"", redfish.OnPowerState, redfish.OffPowerState},
+			transitions: []float64{0, 0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, _ := NewState("")
+			target := targetState{state, "https://bmc.local"}
+
+			for i, powerState := range tt.powerStates {
+				if got := target.observeChassis("1", powerState); got != tt.transitions[i] {
+					t.Errorf("observation %d: got %v transitions, want %v", i, got, tt.transitions[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSettledPowerState(t *testing.T) {
+	tests := []struct {
+		powerState redfish.PowerState
+		previous   redfish.PowerState
+		want       redfish.PowerState
+	}{
+		{redfish.OnPowerState, redfish.OffPowerState, redfish.OnPowerState},
+		{redfish.OffPowerState, redfish.OnPowerState, redfish.OffPowerState},
+		{redfish.OnPowerState, "", redfish.OnPowerState},
+		{redfish.PoweringOnPowerState, redfish.OffPowerState, redfish.OffPowerState},
+		{redfish.PoweringOffPowerState, redfish.OnPowerState, redfish.OnPowerState},
+		{"", redfish.OnPowerState, redfish.OnPowerState},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := settledPowerState(tt.powerState, tt.previous); got != tt.want {
+			t.Errorf("settledPowerState(%q, %q) = %q, want %q", tt.powerState, tt.previous, got, tt.want)
+		}
+	}
+}
+
+func TestTargetKey(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"bmc.local", "bmc.local"},
+		{"bmc.local/", "bmc.local"},
+		{"https://bmc.local", "bmc.local"},
+		{"https://bmc.local/", "bmc.local"},
+		{"https://BMC.local:443", "bmc.local"},
+		{"http://bmc.local:80", "bmc.local"},
+		{"https://bmc.local:8443", "bmc.local:8443"},
+		{"10.0.0.1", "10.0.0.1"},
+		{"https://[fd00::1]:8443/", "[fd00::1]:8443"},
+	}
+
+	for _, tt := range tests {
+		if got := targetKey(tt.endpoint); got != tt.want {
+			t.Errorf("targetKey(%q) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+}
@@ -4,12 +4,154 @@ import (
 	"fmt"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
 	"net"
+	"path"
+	"strconv"
+	"strings"
 )
 
 type SystemCollector struct {
 	client *gofish.APIClient
+	state  targetState
+	bios   *biosInventory
+}
+
+// storageResource holds the storage properties gofish does not expose.
+type storageResource struct {
+	StorageControllers []struct {
+		Oem storageControllerOem
+	}
+	Volumes common.Link
+}
+
+// storageControllerOem holds the Dell and HPE extensions reporting the
+// controller battery and cache module, which the standard schema lacks.
+type storageControllerOem struct {
+	Dell struct {
+		DellControllerBattery *struct {
+			PrimaryStatus string
+		}
+	}
+	Hpe struct {
+		BackupPowerSourceStatus string
+		CacheModuleStatus       struct {
+			Health common.Health
+		}
+	}
+}
+
+// memoryMetrics is a MemoryMetrics resource, including the error counters
+// gofish does not model.
+type memoryMetrics struct {
+	HealthData struct {
+		AlarmTrips                    *redfish.AlarmTrips
+		RemainingSpareBlockPercentage *float64
+	}
+	LifeTime struct {
+		CorrectableECCErrorCount   *float64
+		UncorrectableECCErrorCount *float64
+	}
+}
+
+// pcieDeviceResource holds the PCIe device links gofish does not expose.
+type pcieDeviceResource struct {
+	Links struct {
+		PCIeFunctions []common.Link
+	}
+	PCIeFunctions common.Link
+}
+
+// systemResource holds the system properties gofish does not expose.
+type systemResource struct {
+	BootProgress struct {
+		LastState     string
+		LastStateTime string
+	}
+	LastResetTime string
+}
+
+// processorResource holds the processor properties gofish does not expose.
+type processorResource struct {
+	FirmwareVersion string
+	Links           struct {
+		PCIeFunctions []common.Link
+	}
+	MemorySummary struct {
+		TotalMemorySizeMiB *float64
+	}
+	Metrics         common.Link
+	ProcessorMemory []struct {
+		CapacityMiB float64
+	}
+	Throttled *bool
+}
+
+// memoryBytes returns the total memory attached to the processor, preferring
+// the individual ProcessorMemory entries over the MemorySummary.
+func (r processorResource) memoryBytes() (float64, bool) {
+	if len(r.ProcessorMemory) == 0 {
+		if r.MemorySummary.TotalMemorySizeMiB == nil {
+			return 0, false
+		}
+		return *r.MemorySummary.TotalMemorySizeMiB * mebi, true
+	}
+
+	var total float64
+	for _, m := range r.ProcessorMemory {
+		total += m.CapacityMiB * mebi
+	}
+
+	return total, true
+}
+
+// processorMetrics is a ProcessorMetrics resource.
+type processorMetrics struct {
+	BandwidthPercent  *float64
+	CacheMetricsTotal struct {
+		LifeTime struct {
+			CorrectableECCErrorCount   *float64
+			UncorrectableECCErrorCount *float64
+		}
+	}
+	ConsumedPowerWatt *float64
+	CoreMetrics       []struct {
+		CoreID          string `json:"CoreId"`
+		CStateResidency []struct {
+			Level            string
+			ResidencyPercent float64
+		}
+		CorrectableCoreErrorCount   *float64
+		UncorrectableCoreErrorCount *float64
+	}
+	OperatingSpeedMHz            *float64
+	PowerLimitThrottleDuration   string
+	TemperatureCelsius           *float64
+	ThermalLimitThrottleDuration string
+}
+
+// volume is a Volume resource, including the properties gofish does not model.
+type volume struct {
+	ID            string `json:"Id"`
+	Name          string
+	CapacityBytes int64
+	Encrypted     bool
+	Links         struct {
+		Drives common.Links
+	}
+	Oem struct {
+		Dell struct {
+			DellVolume struct {
+				WriteCachePolicy string
+			}
+		}
+	}
+	Operations       []common.Operations
+	RAIDType         string
+	VolumeType       string
+	WriteCachePolicy string
+	Status           common.Status
 }
 
 func (c *SystemCollector) Collect(ch chan<- prometheus.Metric) error {
@@ -21,6 +163,34 @@ func (c *SystemCollector) Collect(ch chan<- prometheus.Metric) error {
 	for _, system := range systems {
 		c.processSystem(ch, system)
 
+		var resource systemResource
+		if err := getResource(c.client, system.ODataID, &resource); err != nil {
+			return fmt.Errorf("error collecting /Systems/%s: %s", system.ID, err)
+		}
+		resets := c.state.observeSystem(system.ID, resource.LastResetTime, system.PowerState, resource.BootProgress.LastState)
+
+		bios, err := c.bios.get(system)
+		if err != nil {
+			return fmt.Errorf("error collecting /Systems/%s/Bios: %s", system.ID, err)
+		}
+
+		var bootMode string
+		if bios != nil {
+			bootMode = bios.Attributes.String("BootMode")
+		}
+		c.processBoot(ch, system, resource, bootMode, resets)
+
+		secureBoot, err := system.SecureBoot()
+		if err != nil {
+			return fmt.Errorf("error collecting /Systems/%s/SecureBoot: %s", system.ID, err)
+		} else if secureBoot != nil {
+			c.processSecureBoot(ch, secureBoot, system.ID)
+		}
+
+		for i, module := range system.TrustedModules {
+			c.processTrustedModule(ch, module, strconv.Itoa(i), system.ID)
+		}
+
 		ethernetInterfaces, err := system.EthernetInterfaces()
 		if err != nil {
 			return fmt.Errorf("error collecting /Systems/%s/EthernetInterfaces: %s", system.ID, err)
@@ -37,6 +207,19 @@ func (c *SystemCollector) Collect(ch chan<- prometheus.Metric) error {
 
 		for _, memory := range memories {
 			c.processMemory(ch, memory, system.ID)
+
+			var links metricsLink
+			if err := getResource(c.client, memory.ODataID, &links); err != nil {
+				return fmt.Errorf("error collecting /Systems/%s/Memory/%s: %s", system.ID, memory.ID, err)
+			}
+
+			if links.Metrics != "" {
+				var metrics memoryMetrics
+				if err := getResource(c.client, string(links.Metrics), &metrics); err != nil {
+					return fmt.Errorf("error collecting /Systems/%s/Memory/%s/MemoryMetrics: %s", system.ID, memory.ID, err)
+				}
+				c.processMemoryMetrics(ch, memory, metrics, system.ID)
+			}
 		}
 
 		networkInterfaces, err := system.NetworkInterfaces()
@@ -58,6 +241,32 @@ func (c *SystemCollector) Collect(ch chan<- prometheus.Metric) error {
 			if _, processed := devices[device.ID]; !processed {
 				c.processPCIeDevice(ch, device, system.ID)
 				devices[device.ID] = true
+
+				var resource pcieDeviceResource
+				if err := getResource(c.client, device.ODataID, &resource); err != nil {
+					return fmt.Errorf("error collecting /Systems/%s/PCIeDevices/%s: %s", system.ID, device.ID, err)
+				}
+
+				functionLinks := resource.Links.PCIeFunctions
+				if resource.PCIeFunctions != "" {
+					members, err := getMembers(c.client, string(resource.PCIeFunctions))
+					if err != nil {
+						return fmt.Errorf("error collecting /Systems/%s/PCIeDevices/%s/PCIeFunctions: %s", system.ID, device.ID, err)
+					}
+
+					functionLinks = nil
+					for _, member := range members {
+						functionLinks = append(functionLinks, common.Link(member))
+					}
+				}
+
+				for _, link := range functionLinks {
+					function, err := redfish.GetPCIeFunction(c.client, string(link))
+					if err != nil {
+						return fmt.Errorf("error collecting %s: %s", link, err)
+					}
+					c.processPCIeFunction(ch, function, device, system.ID)
+				}
 			}
 		}
 
@@ -67,7 +276,34 @@ func (c *SystemCollector) Collect(ch chan<- prometheus.Metric) error {
 		}
 
 		for _, processor := range processors {
+			var resource processorResource
+			if err := getResource(c.client, processor.ODataID, &resource); err != nil {
+				return fmt.Errorf("error collecting /Systems/%s/Processors/%s: %s", system.ID, processor.ID, err)
+			}
+
+			var metrics processorMetrics
+			if resource.Metrics != "" {
+				if err := getResource(c.client, string(resource.Metrics), &metrics); err != nil {
+					return fmt.Errorf("error collecting /Systems/%s/Processors/%s/ProcessorMetrics: %s", system.ID, processor.ID, err)
+				}
+			}
+
 			c.processProcessor(ch, processor, system.ID)
+
+			switch processor.ProcessorType {
+			case redfish.GPUProcessorType, redfish.FPGAProcessorType, redfish.AcceleratorProcessorType:
+				c.processAccelerator(ch, processor, resource, metrics, system.ID)
+
+				for _, link := range resource.Links.PCIeFunctions {
+					function, err := redfish.GetPCIeFunction(c.client, string(link))
+					if err != nil {
+						return fmt.Errorf("error collecting %s: %s", link, err)
+					}
+					c.processAcceleratorPCIeFunction(ch, processor, function, system.ID)
+				}
+			default:
+				c.processProcessorMetrics(ch, processor, resource, metrics, system.ID)
+			}
 		}
 
 		storages, err := system.Storage()
@@ -78,8 +314,42 @@ func (c *SystemCollector) Collect(ch chan<- prometheus.Metric) error {
 		for _, storage := range storages {
 			c.processStorage(ch, storage, system.ID)
 
+			var links storageResource
+			if err := getResource(c.client, storage.ODataID, &links); err != nil {
+				return fmt.Errorf("error collecting /Systems/%s/Storage/%s: %s", system.ID, storage.ID, err)
+			}
+
+			for i, controller := range storage.StorageControllers {
+				var oem storageControllerOem
+				if i < len(links.StorageControllers) {
+					oem = links.StorageControllers[i].Oem
+				}
+				c.processStorageController(ch, controller, oem, system.ID, storage.ID)
+			}
+
+			driveVolumes := make(map[string][]string)
+			if links.Volumes != "" {
+				members, err := getMembers(c.client, string(links.Volumes))
+				if err != nil {
+					return fmt.Errorf("error collecting /Systems/%s/Storage/%s/Volumes: %s", system.ID, storage.ID, err)
+				}
+
+				for _, member := range members {
+					var v volume
+					if err := getResource(c.client, member, &v); err != nil {
+						return fmt.Errorf("error collecting %s: %s", member, err)
+					}
+					c.processVolume(ch, v, system.ID, storage.ID)
+
+					for _, drive := range v.Links.Drives.ToStrings() {
+						driveVolumes[drive] = append(driveVolumes[drive], v.ID)
+					}
+				}
+			}
+
+			var controllers []string
 			for _, controller := range storage.StorageControllers {
-				c.processStorageController(ch, controller, system.ID, storage.ID)
+				controllers = append(controllers, controller.Name)
 			}
 
 			drives, err := storage.Drives()
@@ -88,6 +358,7 @@ func (c *SystemCollector) Collect(ch chan<- prometheus.Metric) error {
 			}
 			for _, drive := range drives {
 				c.processDrive(ch, drive, system.ID)
+				c.processDriveInfo(ch, drive, system.ID, storage.ID, strings.Join(controllers, ","), driveVolumes[drive.ODataID])
 			}
 		}
 	}
@@ -127,6 +398,128 @@ func (c *SystemCollector) processSystem(ch chan<- prometheus.Metric, system *red
 	}
 }
 
+func (c *SystemCollector) processBoot(ch chan<- prometheus.Metric, system *redfish.ComputerSystem, resource systemResource, bootMode string, resets float64) {
+	constLabels := prometheus.Labels{"id": system.ID, "name": system.Name, "system_id": system.ID, "system_type": string(system.SystemType)}
+
+	lastResetDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "last_reset_timestamp_seconds"),
+		"Time of the last system reset, seconds since epoch",
+		nil, constLabels,
+	)
+	resetsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "resets_total"),
+		"System resets observed by the exporter",
+		nil, constLabels,
+	)
+	bootProgressDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "boot_progress"),
+		"System boot progress; 0: None, 1: PrimaryProcessorInitializationStarted, 2: BusInitializationStarted, 3: MemoryInitializationStarted, 4: SecondaryProcessorInitializationStarted, 5: PCIResourceConfigStarted, 6: SystemHardwareInitializationComplete, 7: SetupEntered, 8: OSBootStarted, 9: OSRunning, 10: OEM",
+		nil, constLabels,
+	)
+	bootProgressTimeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "boot_progress_timestamp_seconds"),
+		"Time the system entered its current boot progress state, seconds since epoch",
+		nil, constLabels,
+	)
+	overrideDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "boot_source_override_enabled"),
+		"System boot source override; 0: Disabled, 1: Once, 2: Continuous",
+		[]string{"target", "mode"}, constLabels,
+	)
+	bootModeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "boot_mode"),
+		"System boot mode; 0: Legacy, 1: UEFI",
+		nil, constLabels,
+	)
+
+	if t, ok := parseTime(resource.LastResetTime); ok {
+		ch <- prometheus.MustNewConstMetric(lastResetDesc, prometheus.GaugeValue, t)
+	}
+	ch <- prometheus.MustNewConstMetric(resetsDesc, prometheus.CounterValue, resets)
+
+	if e := enumBootProgress(resource.BootProgress.LastState); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(bootProgressDesc, prometheus.GaugeValue, e)
+	}
+	if t, ok := parseTime(resource.BootProgress.LastStateTime); ok {
+		ch <- prometheus.MustNewConstMetric(bootProgressTimeDesc, prometheus.GaugeValue, t)
+	}
+
+	boot := system.Boot
+	if e := enumBootSourceOverrideEnabled(boot.BootSourceOverrideEnabled); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(overrideDesc, prometheus.GaugeValue, e, string(boot.BootSourceOverrideTarget), string(boot.BootSourceOverrideMode))
+	}
+
+	// the BIOS attribute holds the mode the system actually boots in, the
+	// override mode only applies to overridden boots, so it is only used if
+	// every boot is overridden
+	e := enumBIOSBootMode(bootMode)
+	if e < 0 && boot.BootSourceOverrideEnabled == redfish.ContinuousBootSourceOverrideEnabled {
+		e = enumBootSourceOverrideMode(boot.BootSourceOverrideMode)
+	}
+	if e >= 0 {
+		ch <- prometheus.MustNewConstMetric(bootModeDesc, prometheus.GaugeValue, e)
+	}
+}
+
+func (c *SystemCollector) processSecureBoot(ch chan<- prometheus.Metric, secureBoot *redfish.SecureBoot, systemID string) {
+	constLabels := prometheus.Labels{"system_id": systemID}
+
+	enabledDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "secure_boot_enabled"),
+		"Secure Boot enabled on next boot; 0: Disabled, 1: Enabled",
+		nil, constLabels,
+	)
+	currentBootDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "secure_boot_current_boot"),
+		"Secure Boot state during the current boot; 0: Disabled, 1: Enabled",
+		nil, constLabels,
+	)
+	modeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "secure_boot_mode"),
+		"Secure Boot mode; 0: SetupMode, 1: UserMode, 2: AuditMode, 3: DeployedMode",
+		nil, constLabels,
+	)
+
+	ch <- prometheus.MustNewConstMetric(enabledDesc, prometheus.GaugeValue, btof(secureBoot.SecureBootEnable))
+
+	if e := enumSecureBootCurrentBoot(secureBoot.SecureBootCurrentBoot); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(currentBootDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumSecureBootMode(secureBoot.SecureBootMode); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(modeDesc, prometheus.GaugeValue, e)
+	}
+}
+
+func (c *SystemCollector) processTrustedModule(ch chan<- prometheus.Metric, module redfish.TrustedModules, moduleID string, systemID string) {
+	constLabels := prometheus.Labels{"id": moduleID, "system_id": systemID, "interface_type": string(module.InterfaceType)}
+
+	infoDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "trusted_module_info"),
+		"Trusted module information",
+		[]string{"firmware_version"}, constLabels,
+	)
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "trusted_module_health"),
+		"Trusted module health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "trusted_module_state"),
+		"Trusted module state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1, module.FirmwareVersion)
+
+	if e := enumHealth(module.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(module.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
+}
+
 func (c *SystemCollector) processEthernetInterface(ch chan<- prometheus.Metric, intf *redfish.EthernetInterface, systemID string) {
 	address, _ := net.ParseMAC(intf.MACAddress)
 
@@ -147,6 +540,11 @@ func (c *SystemCollector) processEthernetInterface(ch chan<- prometheus.Metric,
 		"Ethernet interface link status; 0: LinkDown, 1: LinkUp, 2: NoLink",
 		nil, constLabels,
 	)
+	addressDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "ethernet_interface_address_info"),
+		"Ethernet interface IP address configuration",
+		[]string{"address_family", "ip_address", "prefix_length", "origin", "gateway", "vlan_id", "hostname", "fqdn"}, constLabels,
+	)
 
 	healthDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "system", "ethernet_interface_health"),
@@ -166,6 +564,10 @@ func (c *SystemCollector) processEthernetInterface(ch chan<- prometheus.Metric,
 		ch <- prometheus.MustNewConstMetric(linkStatusDesc, prometheus.GaugeValue, e)
 	}
 
+	for _, a := range interfaceAddresses(intf) {
+		ch <- prometheus.MustNewConstMetric(addressDesc, prometheus.GaugeValue, 1, a.family, a.address, a.prefixLength, a.origin, a.gateway, interfaceVLAN(intf), intf.HostName, intf.FQDN)
+	}
+
 	if e := enumHealth(intf.Status.Health); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
 	}
@@ -228,6 +630,49 @@ func (c *SystemCollector) processMemory(ch chan<- prometheus.Metric, memory *red
 	}
 }
 
+func (c *SystemCollector) processMemoryMetrics(ch chan<- prometheus.Metric, memory *redfish.Memory, metrics memoryMetrics, systemID string) {
+	constLabels := prometheus.Labels{"id": memory.ID, "name": memory.Name, "system_id": systemID, "memory_type": string(memory.MemoryType)}
+
+	correctableDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "memory_correctable_ecc_errors_total"),
+		"Memory correctable ECC errors over the lifetime of the module",
+		nil, constLabels,
+	)
+	uncorrectableDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "memory_uncorrectable_ecc_errors_total"),
+		"Memory uncorrectable ECC errors over the lifetime of the module",
+		nil, constLabels,
+	)
+	spareBlocksDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "memory_spare_blocks_remaining_ratio"),
+		"Memory spare blocks remaining, %",
+		nil, constLabels,
+	)
+	alarmTripDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "memory_alarm_trip"),
+		"Memory alarm trip; 0: Clear, 1: Tripped",
+		[]string{"alarm"}, constLabels,
+	)
+
+	if v := metrics.LifeTime.CorrectableECCErrorCount; v != nil {
+		ch <- prometheus.MustNewConstMetric(correctableDesc, prometheus.CounterValue, *v)
+	}
+	if v := metrics.LifeTime.UncorrectableECCErrorCount; v != nil {
+		ch <- prometheus.MustNewConstMetric(uncorrectableDesc, prometheus.CounterValue, *v)
+	}
+	if v := metrics.HealthData.RemainingSpareBlockPercentage; v != nil {
+		ch <- prometheus.MustNewConstMetric(spareBlocksDesc, prometheus.GaugeValue, *v/100)
+	}
+
+	if alarms := metrics.HealthData.AlarmTrips; alarms != nil {
+		ch <- prometheus.MustNewConstMetric(alarmTripDesc, prometheus.GaugeValue, btof(alarms.AddressParityError), "address_parity_error")
+		ch <- prometheus.MustNewConstMetric(alarmTripDesc, prometheus.GaugeValue, btof(alarms.CorrectableECCError), "correctable_ecc_error")
+		ch <- prometheus.MustNewConstMetric(alarmTripDesc, prometheus.GaugeValue, btof(alarms.SpareBlock), "spare_block")
+		ch <- prometheus.MustNewConstMetric(alarmTripDesc, prometheus.GaugeValue, btof(alarms.Temperature), "temperature")
+		ch <- prometheus.MustNewConstMetric(alarmTripDesc, prometheus.GaugeValue, btof(alarms.UncorrectableECCError), "uncorrectable_ecc_error")
+	}
+}
+
 func (c *SystemCollector) processNetworkInterface(ch chan<- prometheus.Metric, intf *redfish.NetworkInterface, systemID string) {
 	constLabels := prometheus.Labels{"id": intf.ID, "name": intf.Name, "system_id": systemID}
 
@@ -264,12 +709,92 @@ func (c *SystemCollector) processPCIeDevice(ch chan<- prometheus.Metric, device
 		nil, constLabels,
 	)
 
+	lanesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "pcie_device_lanes"),
+		"PCIe device lanes in use",
+		nil, constLabels,
+	)
+	maxLanesDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "pcie_device_lanes_max"),
+		"Maximum PCIe device lanes",
+		nil, constLabels,
+	)
+	generationDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "pcie_device_generation"),
+		"Negotiated PCIe device generation",
+		nil, constLabels,
+	)
+	maxGenerationDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "pcie_device_generation_max"),
+		"Maximum PCIe device generation",
+		nil, constLabels,
+	)
+	degradedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "pcie_device_link_degraded"),
+		"PCIe device link running below its maximum lanes or generation; 0: No, 1: Yes",
+		nil, constLabels,
+	)
+
 	if e := enumHealth(device.Status.Health); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
 	}
 	if e := enumState(device.Status.State); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
 	}
+
+	intf := device.PCIeInterface
+	if intf.LanesInUse > 0 {
+		ch <- prometheus.MustNewConstMetric(lanesDesc, prometheus.GaugeValue, float64(intf.LanesInUse))
+	}
+	if intf.MaxLanes > 0 {
+		ch <- prometheus.MustNewConstMetric(maxLanesDesc, prometheus.GaugeValue, float64(intf.MaxLanes))
+	}
+
+	generation, maxGeneration := enumPCIeType(intf.PCIeType), enumPCIeType(intf.MaxPCIeType)
+	if generation >= 0 {
+		ch <- prometheus.MustNewConstMetric(generationDesc, prometheus.GaugeValue, generation)
+	}
+	if maxGeneration >= 0 {
+		ch <- prometheus.MustNewConstMetric(maxGenerationDesc, prometheus.GaugeValue, maxGeneration)
+	}
+
+	lanesKnown := intf.LanesInUse > 0 && intf.MaxLanes > 0
+	generationKnown := generation >= 0 && maxGeneration >= 0
+	if lanesKnown || generationKnown {
+		degraded := (lanesKnown && intf.LanesInUse < intf.MaxLanes) || (generationKnown && generation < maxGeneration)
+		ch <- prometheus.MustNewConstMetric(degradedDesc, prometheus.GaugeValue, btof(degraded))
+	}
+}
+
+func (c *SystemCollector) processPCIeFunction(ch chan<- prometheus.Metric, function *redfish.PCIeFunction, device *redfish.PCIeDevice, systemID string) {
+	constLabels := prometheus.Labels{"id": function.ID, "name": function.Name, "system_id": systemID, "pcie_device_id": device.ID}
+
+	infoDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "pcie_function_info"),
+		"PCIe function information",
+		[]string{"function_type", "device_class", "vendor_id", "device_id", "subsystem_vendor_id", "subsystem_id"}, constLabels,
+	)
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "pcie_function_health"),
+		"PCIe function health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "pcie_function_state"),
+		"PCIe function state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1,
+		string(function.FunctionType), string(function.DeviceClass), function.VendorID, function.DeviceID, function.SubsystemVendorID, function.SubsystemID)
+
+	if e := enumHealth(function.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(function.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
 }
 
 func (c *SystemCollector) processProcessor(ch chan<- prometheus.Metric, processor *redfish.Processor, systemID string) {
@@ -332,6 +857,205 @@ func (c *SystemCollector) processProcessor(ch chan<- prometheus.Metric, processo
 	}
 }
 
+func (c *SystemCollector) processProcessorMetrics(ch chan<- prometheus.Metric, processor *redfish.Processor, resource processorResource, metrics processorMetrics, systemID string) {
+	constLabels := prometheus.Labels{"id": processor.ID, "name": processor.Name, "system_id": systemID, "processor_type": string(processor.ProcessorType)}
+
+	temperatureDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_temperature_celsius"),
+		"Processor temperature, °C",
+		nil, constLabels,
+	)
+	powerDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_power_consumed_watts"),
+		"Processor power consumption, W",
+		nil, constLabels,
+	)
+	speedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_speed_operating_hertz"),
+		"Processor operating speed, Hz",
+		nil, constLabels,
+	)
+	bandwidthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_bandwidth_ratio"),
+		"Processor bandwidth utilization, %",
+		nil, constLabels,
+	)
+	throttledDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_throttled"),
+		"Processor throttling status; 0: NotThrottled, 1: Throttled",
+		nil, constLabels,
+	)
+	throttleDurationDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_throttle_seconds_total"),
+		"Processor time spent throttled since reset, s",
+		[]string{"cause"}, constLabels,
+	)
+	cacheCorrectableDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_cache_correctable_ecc_errors_total"),
+		"Processor cache correctable ECC errors over the lifetime of the processor",
+		nil, constLabels,
+	)
+	cacheUncorrectableDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_cache_uncorrectable_ecc_errors_total"),
+		"Processor cache uncorrectable ECC errors over the lifetime of the processor",
+		nil, constLabels,
+	)
+	coreCStateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_core_c_state_residency_ratio"),
+		"Processor core time spent in a C-state, %",
+		[]string{"core_id", "level"}, constLabels,
+	)
+	coreCorrectableDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_core_correctable_errors_total"),
+		"Processor core correctable errors",
+		[]string{"core_id"}, constLabels,
+	)
+	coreUncorrectableDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "processor_core_uncorrectable_errors_total"),
+		"Processor core uncorrectable errors",
+		[]string{"core_id"}, constLabels,
+	)
+
+	if v := metrics.TemperatureCelsius; v != nil {
+		ch <- prometheus.MustNewConstMetric(temperatureDesc, prometheus.GaugeValue, *v)
+	}
+	if v := metrics.ConsumedPowerWatt; v != nil {
+		ch <- prometheus.MustNewConstMetric(powerDesc, prometheus.GaugeValue, *v)
+	}
+	if v := metrics.OperatingSpeedMHz; v != nil {
+		ch <- prometheus.MustNewConstMetric(speedDesc, prometheus.GaugeValue, *v*mega)
+	}
+	if v := metrics.BandwidthPercent; v != nil {
+		ch <- prometheus.MustNewConstMetric(bandwidthDesc, prometheus.GaugeValue, *v/100)
+	}
+
+	if resource.Throttled != nil {
+		ch <- prometheus.MustNewConstMetric(throttledDesc, prometheus.GaugeValue, btof(*resource.Throttled))
+	}
+	if d, ok := parseDuration(metrics.ThermalLimitThrottleDuration); ok {
+		ch <- prometheus.MustNewConstMetric(throttleDurationDesc, prometheus.CounterValue, d, "thermal")
+	}
+	if d, ok := parseDuration(metrics.PowerLimitThrottleDuration); ok {
+		ch <- prometheus.MustNewConstMetric(throttleDurationDesc, prometheus.CounterValue, d, "power")
+	}
+
+	if v := metrics.CacheMetricsTotal.LifeTime.CorrectableECCErrorCount; v != nil {
+		ch <- prometheus.MustNewConstMetric(cacheCorrectableDesc, prometheus.CounterValue, *v)
+	}
+	if v := metrics.CacheMetricsTotal.LifeTime.UncorrectableECCErrorCount; v != nil {
+		ch <- prometheus.MustNewConstMetric(cacheUncorrectableDesc, prometheus.CounterValue, *v)
+	}
+
+	for _, core := range metrics.CoreMetrics {
+		for _, cstate := range core.CStateResidency {
+			ch <- prometheus.MustNewConstMetric(coreCStateDesc, prometheus.GaugeValue, cstate.ResidencyPercent/100, core.CoreID, cstate.Level)
+		}
+		if v := core.CorrectableCoreErrorCount; v != nil {
+			ch <- prometheus.MustNewConstMetric(coreCorrectableDesc, prometheus.CounterValue, *v, core.CoreID)
+		}
+		if v := core.UncorrectableCoreErrorCount; v != nil {
+			ch <- prometheus.MustNewConstMetric(coreUncorrectableDesc, prometheus.CounterValue, *v, core.CoreID)
+		}
+	}
+}
+
+func (c *SystemCollector) processAccelerator(ch chan<- prometheus.Metric, processor *redfish.Processor, resource processorResource, metrics processorMetrics, systemID string) {
+	constLabels := prometheus.Labels{"id": processor.ID, "name": processor.Name, "system_id": systemID, "accelerator_type": string(processor.ProcessorType)}
+
+	infoDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_info"),
+		"Accelerator information",
+		[]string{"manufacturer", "model", "firmware_version"}, constLabels,
+	)
+	memoryDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_memory_bytes"),
+		"Accelerator memory size, bytes",
+		nil, constLabels,
+	)
+	temperatureDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_temperature_celsius"),
+		"Accelerator temperature, °C",
+		nil, constLabels,
+	)
+	powerDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_power_consumed_watts"),
+		"Accelerator power consumption, W",
+		nil, constLabels,
+	)
+	maxTDPDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_tdp_max_watts"),
+		"Maximum accelerator TDP, W",
+		nil, constLabels,
+	)
+	speedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_speed_operating_hertz"),
+		"Accelerator operating speed, Hz",
+		nil, constLabels,
+	)
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_health"),
+		"Accelerator health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_state"),
+		"Accelerator state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	firmwareVersion := resource.FirmwareVersion
+	if firmwareVersion == "" {
+		firmwareVersion = processor.FPGA.FirmwareVersion
+	}
+	ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1, processor.Manufacturer, processor.Model, firmwareVersion)
+
+	if v, ok := resource.memoryBytes(); ok {
+		ch <- prometheus.MustNewConstMetric(memoryDesc, prometheus.GaugeValue, v)
+	}
+	if v := metrics.TemperatureCelsius; v != nil {
+		ch <- prometheus.MustNewConstMetric(temperatureDesc, prometheus.GaugeValue, *v)
+	}
+	if v := metrics.ConsumedPowerWatt; v != nil {
+		ch <- prometheus.MustNewConstMetric(powerDesc, prometheus.GaugeValue, *v)
+	}
+	if processor.MaxTDPWatts > 0 {
+		ch <- prometheus.MustNewConstMetric(maxTDPDesc, prometheus.GaugeValue, float64(processor.MaxTDPWatts))
+	}
+	if v := metrics.OperatingSpeedMHz; v != nil {
+		ch <- prometheus.MustNewConstMetric(speedDesc, prometheus.GaugeValue, *v*mega)
+	}
+
+	if e := enumHealth(processor.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(processor.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
+}
+
+func (c *SystemCollector) processAcceleratorPCIeFunction(ch chan<- prometheus.Metric, processor *redfish.Processor, function *redfish.PCIeFunction, systemID string) {
+	constLabels := prometheus.Labels{"id": processor.ID, "name": processor.Name, "system_id": systemID, "function_id": function.ID}
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_pcie_function_health"),
+		"Accelerator PCIe function health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "accelerator_pcie_function_state"),
+		"Accelerator PCIe function state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	if e := enumHealth(function.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(function.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
+}
+
 func (c *SystemCollector) processStorage(ch chan<- prometheus.Metric, storage *redfish.Storage, systemID string) {
 	constLabels := prometheus.Labels{"id": storage.ID, "name": storage.Name, "system_id": systemID, "storage_id": storage.ID}
 
@@ -354,7 +1078,7 @@ func (c *SystemCollector) processStorage(ch chan<- prometheus.Metric, storage *r
 	}
 }
 
-func (c *SystemCollector) processStorageController(ch chan<- prometheus.Metric, controller redfish.StorageController, systemID string, storageID string) {
+func (c *SystemCollector) processStorageController(ch chan<- prometheus.Metric, controller redfish.StorageController, oem storageControllerOem, systemID string, storageID string) {
 	constLabels := prometheus.Labels{"id": controller.MemberID, "name": controller.Name, "system_id": systemID, "storage_id": storageID}
 
 	speedDesc := prometheus.NewDesc(
@@ -373,13 +1097,29 @@ func (c *SystemCollector) processStorageController(ch chan<- prometheus.Metric,
 		nil, constLabels,
 	)
 
+	infoDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "storage_controller_info"),
+		"Storage controller identification",
+		[]string{"manufacturer", "model", "firmware_version"}, constLabels,
+	)
+	batteryPresentDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "storage_controller_battery_present"),
+		"Storage controller battery presence; 0: NotPresent, 1: Present",
+		nil, constLabels,
+	)
+	batteryHealthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "storage_controller_battery_health"),
+		"Storage controller battery health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+
 	cacheHealthDesc := prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, "system", "storage_controller_health"),
+		prometheus.BuildFQName(namespace, "system", "storage_controller_cache_health"),
 		"Storage controller cache health; 0: OK, 1: Warning, 2: Critical",
 		nil, constLabels,
 	)
 	cacheStateDesc := prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, "system", "storage_controller_state"),
+		prometheus.BuildFQName(namespace, "system", "storage_controller_cache_state"),
 		"Storage controller cache state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
 		nil, constLabels,
 	)
@@ -398,7 +1138,22 @@ func (c *SystemCollector) processStorageController(ch chan<- prometheus.Metric,
 	ch <- prometheus.MustNewConstMetric(persistentCacheSizeDesc, prometheus.GaugeValue, float64(controller.CacheSummary.PersistentCacheSizeMiB)*mebi)
 	ch <- prometheus.MustNewConstMetric(cacheSizeDesc, prometheus.GaugeValue, float64(controller.CacheSummary.TotalCacheSizeMiB)*mebi)
 
-	if e := enumHealth(controller.CacheSummary.Status.Health); e >= 0 {
+	ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1, controller.Manufacturer, controller.Model, controller.FirmwareVersion)
+
+	if battery := oem.Dell.DellControllerBattery; battery != nil {
+		ch <- prometheus.MustNewConstMetric(batteryPresentDesc, prometheus.GaugeValue, 1)
+		if e := enumDellPrimaryStatus(battery.PrimaryStatus); e >= 0 {
+			ch <- prometheus.MustNewConstMetric(batteryHealthDesc, prometheus.GaugeValue, e)
+		}
+	} else if e := enumBackupPowerSourceStatus(oem.Hpe.BackupPowerSourceStatus); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(batteryPresentDesc, prometheus.GaugeValue, e)
+	}
+
+	cacheHealth := controller.CacheSummary.Status.Health
+	if cacheHealth == "" {
+		cacheHealth = oem.Hpe.CacheModuleStatus.Health
+	}
+	if e := enumHealth(cacheHealth); e >= 0 {
 		ch <- prometheus.MustNewConstMetric(cacheHealthDesc, prometheus.GaugeValue, e)
 	}
 	if e := enumState(controller.CacheSummary.Status.State); e >= 0 {
@@ -508,6 +1263,254 @@ func (c *SystemCollector) processDrive(ch chan<- prometheus.Metric, drive *redfi
 	}
 }
 
+func (c *SystemCollector) processDriveInfo(ch chan<- prometheus.Metric, drive *redfish.Drive, systemID string, storageID string, controller string, volumes []string) {
+	constLabels := prometheus.Labels{"id": drive.ID, "name": drive.Name, "system_id": systemID, "drive_type": string(drive.MediaType)}
+
+	infoDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "drive_info"),
+		"Drive topology and identification",
+		[]string{"storage_id", "storage_controller", "volumes", "location", "serial_number", "model", "protocol"}, constLabels,
+	)
+
+	ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1, storageID, controller, strings.Join(volumes, ","), driveLocation(drive), drive.SerialNumber, drive.Model, string(drive.Protocol))
+}
+
+func (c *SystemCollector) processVolume(ch chan<- prometheus.Metric, v volume, systemID string, storageID string) {
+	constLabels := prometheus.Labels{"id": v.ID, "name": v.Name, "system_id": systemID, "storage_id": storageID, "raid_type": v.RAIDType, "volume_type": v.VolumeType}
+
+	capacityDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "volume_capacity_bytes"),
+		"Volume capacity, bytes",
+		nil, constLabels,
+	)
+	encryptedDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "volume_encrypted"),
+		"Volume encryption status; 0: Unencrypted, 1: Encrypted",
+		nil, constLabels,
+	)
+	operationDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "volume_operation_completed_ratio"),
+		"Volume operation in progress completion, %",
+		[]string{"operation"}, constLabels,
+	)
+	writeCacheDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "volume_write_cache_policy"),
+		"Volume write cache policy; 0: Off, 1: WriteThrough, 2: ProtectedWriteBack, 3: UnprotectedWriteBack",
+		nil, constLabels,
+	)
+	driveDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "volume_drive_info"),
+		"Volume member drive",
+		[]string{"drive_id"}, constLabels,
+	)
+
+	healthDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "volume_health"),
+		"Volume health; 0: OK, 1: Warning, 2: Critical",
+		nil, constLabels,
+	)
+	stateDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system", "volume_state"),
+		"Volume state; 0: Disabled, 1: Enabled, 2: StandbyOffline, 3: StandbySpare, 4: InTest, 5: Starting, 6: Absent, 7: UnavailableOffline, 8: Deferring, 9: Quiesced, 10: Updating",
+		nil, constLabels,
+	)
+
+	ch <- prometheus.MustNewConstMetric(capacityDesc, prometheus.GaugeValue, float64(v.CapacityBytes))
+	ch <- prometheus.MustNewConstMetric(encryptedDesc, prometheus.GaugeValue, btof(v.Encrypted))
+
+	writeCachePolicy := v.WriteCachePolicy
+	if writeCachePolicy == "" {
+		writeCachePolicy = v.Oem.Dell.DellVolume.WriteCachePolicy
+	}
+	if e := enumWriteCachePolicy(writeCachePolicy); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(writeCacheDesc, prometheus.GaugeValue, e)
+	}
+
+	for _, operation := range v.Operations {
+		ch <- prometheus.MustNewConstMetric(operationDesc, prometheus.GaugeValue, float64(operation.PercentageComplete)/100, operation.OperationName)
+	}
+	for _, drive := range v.Links.Drives.ToStrings() {
+		ch <- prometheus.MustNewConstMetric(driveDesc, prometheus.GaugeValue, 1, path.Base(drive))
+	}
+
+	if e := enumHealth(v.Status.Health); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, e)
+	}
+	if e := enumState(v.Status.State); e >= 0 {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, e)
+	}
+}
+
+// driveLocation returns the bay or slot of a drive, as reported in
+// PhysicalLocation or, failing that, the deprecated Location property.
+func driveLocation(drive *redfish.Drive) string {
+	part := drive.PhysicalLocation.PartLocation
+	if part.ServiceLabel != "" {
+		return part.ServiceLabel
+	}
+	if part.LocationType != "" {
+		return string(part.LocationType) + " " + strconv.Itoa(part.LocationOrdinalValue)
+	}
+
+	for _, location := range drive.Location {
+		if location.Info != "" {
+			return location.Info
+		}
+	}
+
+	return ""
+}
+
+func enumBackupPowerSourceStatus(e string) float64 {
+	switch e {
+	case "NotPresent":
+		return 0
+	case "Present":
+		return 1
+	default:
+		return -1
+	}
+}
+
+// enumDellPrimaryStatus maps the Dell status, which reports Degraded and Error
+// rather than Warning and Critical, onto the health values.
+func enumDellPrimaryStatus(e string) float64 {
+	switch e {
+	case "Degraded":
+		return 1
+	case "Error":
+		return 2
+	default:
+		return enumHealth(common.Health(e))
+	}
+}
+
+// enumWriteCachePolicy maps both the standard and the Dell write cache
+// policies.
+func enumWriteCachePolicy(e string) float64 {
+	switch e {
+	case "Off":
+		return 0
+	case "WriteThrough":
+		return 1
+	case "ProtectedWriteBack", "WriteBack":
+		return 2
+	case "UnprotectedWriteBack", "ForceWriteBack", "AlwaysWriteBack":
+		return 3
+	default:
+		return -1
+	}
+}
+
+func enumBootProgress(e string) float64 {
+	switch e {
+	case "None":
+		return 0
+	case "PrimaryProcessorInitializationStarted":
+		return 1
+	case "BusInitializationStarted":
+		return 2
+	case "MemoryInitializationStarted":
+		return 3
+	case "SecondaryProcessorInitializationStarted":
+		return 4
+	case "PCIResourceConfigStarted":
+		return 5
+	case "SystemHardwareInitializationComplete":
+		return 6
+	case "SetupEntered":
+		return 7
+	case "OSBootStarted":
+		return 8
+	case "OSRunning":
+		return 9
+	case "OEM":
+		return 10
+	default:
+		return -1
+	}
+}
+
+func enumBootSourceOverrideEnabled(e redfish.BootSourceOverrideEnabled) float64 {
+	switch e {
+	case redfish.DisabledBootSourceOverrideEnabled:
+		return 0
+	case redfish.OnceBootSourceOverrideEnabled:
+		return 1
+	case redfish.ContinuousBootSourceOverrideEnabled:
+		return 2
+	default:
+		return -1
+	}
+}
+
+func enumBIOSBootMode(e string) float64 {
+	switch e {
+	case "Bios", "LegacyBios", "Legacy":
+		return 0
+	case "Uefi", "UEFI":
+		return 1
+	default:
+		return -1
+	}
+}
+
+func enumBootSourceOverrideMode(e redfish.BootSourceOverrideMode) float64 {
+	switch e {
+	case redfish.LegacyBootSourceOverrideMode:
+		return 0
+	case redfish.UEFIBootSourceOverrideMode:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func enumSecureBootCurrentBoot(e redfish.SecureBootCurrentBootType) float64 {
+	switch e {
+	case redfish.DisabledSecureBootCurrentBootType:
+		return 0
+	case redfish.EnabledSecureBootCurrentBootType:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func enumSecureBootMode(e redfish.SecureBootModeType) float64 {
+	switch e {
+	case redfish.SetupModeSecureBootModeType:
+		return 0
+	case redfish.UserModeSecureBootModeType:
+		return 1
+	case redfish.AuditModeSecureBootModeType:
+		return 2
+	case redfish.DeployedModeSecureBootModeType:
+		return 3
+	default:
+		return -1
+	}
+}
+
+func enumPCIeType(e redfish.PCIeTypes) float64 {
+	switch e {
+	case redfish.Gen1PCIeTypes:
+		return 1
+	case redfish.Gen2PCIeTypes:
+		return 2
+	case redfish.Gen3PCIeTypes:
+		return 3
+	case redfish.Gen4PCIeTypes:
+		return 4
+	case redfish.Gen5PCIeTypes:
+		return 5
+	case "Gen6":
+		return 6
+	default:
+		return -1
+	}
+}
+
 func enumInterfaceLinkStatus(e redfish.LinkStatus) float64 {
 	switch e {
 	case redfish.LinkDownLinkStatus:
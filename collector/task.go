@@ -0,0 +1,286 @@
+package collector
+
+import (
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"time"
+)
+
+// maxTasks caps the tasks read one by one from a queue, as job queues often
+// hold hundreds of entries.
+const maxTasks = 50
+
+type TaskCollector struct {
+	client *gofish.APIClient
+}
+
+type taskService struct {
+	Tasks common.Link
+}
+
+type jobService struct {
+	Jobs common.Link
+}
+
+// dellManagerLinks holds the link to the Dell Lifecycle Controller job queue.
+type dellManagerLinks struct {
+	Links struct {
+		Oem struct {
+			Dell struct {
+				Jobs common.Link
+			}
+		}
+	}
+}
+
+// task is a Redfish task or job, or a Dell Lifecycle Controller job. Tasks
+// are decoded locally as gofish reads their messages as links.
+type task struct {
+	ID              string `json:"Id"`
+	Name            string
+	TaskState       string
+	JobState        string
+	PercentComplete *float64
+	StartTime       string
+	EndTime         string
+	// Dell jobs report CompletionTime and a single Message instead
+	CompletionTime string
+	Message        string
+	Messages       []struct {
+		Message string
+	}
+}
+
+func (t task) state() string {
+	if t.TaskState != "" {
+		return t.TaskState
+	}
+	return t.JobState
+}
+
+func (t task) endTime() (float64, bool) {
+	if v, ok := parseTaskTime(t.EndTime); ok {
+		return v, true
+	}
+	return parseTaskTime(t.CompletionTime)
+}
+
+// parseTaskTime parses a task timestamp, which Dell jobs report without a
+// UTC offset.
+func parseTaskTime(s string) (float64, bool) {
+	if v, ok := parseTime(s); ok {
+		return v, true
+	}
+
+	if v, err := time.Parse("2006-01-02T15:04:05", s); err == nil {
+		return float64(v.Unix()), true
+	}
+
+	return 0, false
+}
+
+func (t task) message() string {
+	if len(t.Messages) > 0 {
+		return t.Messages[len(t.Messages)-1].Message
+	}
+	return t.Message
+}
+
+func (c *TaskCollector) Collect(ch chan<- prometheus.Metric) error {
+	var root serviceRootLinks
+	if err := getResource(c.client, common.DefaultServiceRoot, &root); err != nil {
+		return fmt.Errorf("error collecting service root: %s", err)
+	}
+
+	if root.Tasks != "" {
+		var service taskService
+		if err := getResource(c.client, string(root.Tasks), &service); err != nil {
+			return fmt.Errorf("error collecting /TaskService: %s", err)
+		}
+
+		if service.Tasks != "" {
+			tasks, unread, err := c.getTasks(string(service.Tasks))
+			if err != nil {
+				return fmt.Errorf("error collecting /TaskService/Tasks: %s", err)
+			}
+			c.processTasks(ch, tasks, unread, "task")
+		}
+	}
+
+	var jobQueue bool
+	if root.JobService != "" {
+		var service jobService
+		if err := getResource(c.client, string(root.JobService), &service); err != nil {
+			return fmt.Errorf("error collecting /JobService: %s", err)
+		}
+
+		if service.Jobs != "" {
+			jobs, unread, err := c.getTasks(string(service.Jobs))
+			if err != nil {
+				return fmt.Errorf("error collecting /JobService/Jobs: %s", err)
+			}
+			c.processTasks(ch, jobs, unread, "job")
+			jobQueue = true
+		}
+	}
+
+	// iDRAC lists the same jobs in the JobService, where it has one
+	if jobQueue {
+		return nil
+	}
+
+	managers, err := c.client.Service.Managers()
+	if err != nil {
+		return fmt.Errorf("error collecting /Managers: %s", err)
+	}
+
+	for _, manager := range managers {
+		var links dellManagerLinks
+		if err := getResource(c.client, manager.ODataID, &links); err != nil {
+			return fmt.Errorf("error collecting /Managers/%s: %s", manager.ID, err)
+		}
+
+		if jobsLink := links.Links.Oem.Dell.Jobs; jobsLink != "" {
+			jobs, unread, err := c.getTasks(string(jobsLink))
+			if err != nil {
+				return fmt.Errorf("error collecting /Managers/%s/Jobs: %s", manager.ID, err)
+			}
+			c.processTasks(ch, jobs, unread, "dell_job")
+		}
+	}
+
+	return nil
+}
+
+// getTasks returns the tasks in the queue at uri, along with the number of
+// tasks left unread. Where the service supports $expand the queue is read in
+// one request, otherwise only the most recent maxTasks tasks are read.
+func (c *TaskCollector) getTasks(uri string) ([]task, int, error) {
+	expand := c.client.Service.ProtocolFeaturesSupported.ExpandQuery
+	if expand.NoLinks || expand.ExpandAll {
+		query := "*"
+		if expand.NoLinks {
+			query = "."
+		}
+
+		var collection struct {
+			Members []task
+		}
+		if err := getResource(c.client, uri+"?$expand="+query, &collection); err != nil {
+			return nil, 0, err
+		}
+
+		// some services advertise $expand but ignore it for OEM collections
+		expanded := true
+		for _, t := range collection.Members {
+			expanded = expanded && t.state() != ""
+		}
+		if expanded {
+			return collection.Members, 0, nil
+		}
+	}
+
+	members, err := getMembers(c.client, uri)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// queues list the oldest tasks first
+	var unread int
+	if len(members) > maxTasks {
+		unread = len(members) - maxTasks
+		members = members[unread:]
+	}
+
+	tasks := make([]task, len(members))
+	for i, member := range members {
+		if err := getResource(c.client, member, &tasks[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return tasks, unread, nil
+}
+
+func (c *TaskCollector) processTasks(ch chan<- prometheus.Metric, tasks []task, unread int, queue string) {
+	constLabels := prometheus.Labels{"queue": queue}
+
+	countDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "tasks"),
+		"Tasks in the queue",
+		[]string{"state"}, constLabels,
+	)
+	unreadDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "tasks_unread"),
+		"Tasks in the queue beyond the read limit, in an unknown state",
+		nil, constLabels,
+	)
+	percentCompleteDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "task", "percent_complete_ratio"),
+		"Unfinished task progress, %",
+		[]string{"id", "name", "state"}, constLabels,
+	)
+	ageDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "task", "age_seconds"),
+		"Time since an unfinished task started, s",
+		[]string{"id", "name", "state"}, constLabels,
+	)
+	lastFailureDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "task", "last_failure_timestamp_seconds"),
+		"End time of the most recent failed task, seconds since epoch; 0 if unknown",
+		[]string{"id", "name", "state", "message"}, constLabels,
+	)
+
+	now := float64(time.Now().Unix())
+
+	counts := make(map[string]float64)
+	var lastFailure *task
+	var lastFailureTime float64
+
+	for i, t := range tasks {
+		state := t.state()
+		counts[state]++
+
+		switch {
+		case taskFailed(state):
+			end, _ := t.endTime()
+			if lastFailure == nil || end >= lastFailureTime {
+				lastFailure, lastFailureTime = &tasks[i], end
+			}
+		case !taskFinished(state):
+			if v := t.PercentComplete; v != nil {
+				ch <- prometheus.MustNewConstMetric(percentCompleteDesc, prometheus.GaugeValue, *v/100, t.ID, t.Name, state)
+			}
+			// Dell jobs report TIME_NOW as start time until they run
+			if start, ok := parseTaskTime(t.StartTime); ok {
+				ch <- prometheus.MustNewConstMetric(ageDesc, prometheus.GaugeValue, now-start, t.ID, t.Name, state)
+			}
+		}
+	}
+
+	for state, count := range counts {
+		ch <- prometheus.MustNewConstMetric(countDesc, prometheus.GaugeValue, count, state)
+	}
+	ch <- prometheus.MustNewConstMetric(unreadDesc, prometheus.GaugeValue, float64(unread))
+
+	if lastFailure != nil {
+		ch <- prometheus.MustNewConstMetric(lastFailureDesc, prometheus.GaugeValue, lastFailureTime, lastFailure.ID, lastFailure.Name, lastFailure.state(), lastFailure.message())
+	}
+}
+
+// taskFailed reports whether a task or job in state e ended unsuccessfully.
+func taskFailed(e string) bool {
+	switch e {
+	case "Exception", "Killed", "Failed", "CompletedWithErrors":
+		return true
+	default:
+		return false
+	}
+}
+
+// taskFinished reports whether a task or job in state e is no longer running.
+func taskFinished(e string) bool {
+	return taskFailed(e) || e == "Completed" || e == "Cancelled"
+}
@@ -0,0 +1,174 @@
+package collector
+
+import (
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"log"
+	"path"
+	"strconv"
+	"time"
+)
+
+type TelemetryCollector struct {
+	client  *gofish.APIClient
+	reports []string
+	// timestamps exports values at the time the BMC took them rather than at
+	// scrape time, which Prometheus drops if the BMC clock is off
+	timestamps bool
+}
+
+type telemetryService struct {
+	MetricReportDefinitions common.Link
+	MetricReports           common.Link
+}
+
+type metricReportDefinition struct {
+	MetricReport common.Link
+}
+
+type metricReport struct {
+	ID           string `json:"Id"`
+	Timestamp    string
+	MetricValues []metricValue
+}
+
+type metricValue struct {
+	MetricID       string `json:"MetricId"`
+	MetricProperty string
+	MetricValue    string
+	Timestamp      string
+	// iDRAC leaves MetricProperty empty and identifies the source here
+	Oem struct {
+		Dell struct {
+			ContextID string
+		}
+	}
+}
+
+func (c *TelemetryCollector) Collect(ch chan<- prometheus.Metric) error {
+	if len(c.reports) == 0 {
+		return nil
+	}
+
+	var root serviceRootLinks
+	if err := getResource(c.client, common.DefaultServiceRoot, &root); err != nil {
+		return fmt.Errorf("error collecting service root: %s", err)
+	}
+
+	if root.TelemetryService == "" {
+		log.Printf("metric reports configured, but the service does not support telemetry")
+		return nil
+	}
+
+	var service telemetryService
+	if err := getResource(c.client, string(root.TelemetryService), &service); err != nil {
+		return fmt.Errorf("error collecting /TelemetryService: %s", err)
+	}
+
+	definitions, err := c.getMemberLinks(string(service.MetricReportDefinitions))
+	if err != nil {
+		return fmt.Errorf("error collecting /TelemetryService/MetricReportDefinitions: %s", err)
+	}
+
+	reports, err := c.getMemberLinks(string(service.MetricReports))
+	if err != nil {
+		return fmt.Errorf("error collecting /TelemetryService/MetricReports: %s", err)
+	}
+
+	for _, name := range c.reports {
+		uri, ok := reports[name]
+		if definition, isDefinition := definitions[name]; isDefinition {
+			var d metricReportDefinition
+			if err := getResource(c.client, definition, &d); err != nil {
+				return fmt.Errorf("error collecting /TelemetryService/MetricReportDefinitions/%s: %s", name, err)
+			}
+			if d.MetricReport != "" {
+				uri, ok = string(d.MetricReport), true
+			}
+		}
+		if !ok {
+			log.Printf("metric report %s not found, skipping", name)
+			continue
+		}
+
+		var report metricReport
+		if err := getResource(c.client, uri, &report); err != nil {
+			return fmt.Errorf("error collecting /TelemetryService/MetricReports/%s: %s", name, err)
+		}
+
+		c.processMetricReport(ch, report, name)
+	}
+
+	return nil
+}
+
+// getMemberLinks returns the members of the collection at uri keyed by their
+// last path segment, which is the Id of the member.
+func (c *TelemetryCollector) getMemberLinks(uri string) (map[string]string, error) {
+	links := make(map[string]string)
+	if uri == "" {
+		return links, nil
+	}
+
+	members, err := getMembers(c.client, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		links[path.Base(member)] = member
+	}
+
+	return links, nil
+}
+
+func (c *TelemetryCollector) processMetricReport(ch chan<- prometheus.Metric, report metricReport, name string) {
+	constLabels := prometheus.Labels{"report": name}
+
+	timestampDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "telemetry", "report_timestamp_seconds"),
+		"Metric report generation time, seconds since epoch",
+		nil, constLabels,
+	)
+	valueDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "telemetry", "metric_value"),
+		"Metric report value",
+		[]string{"metric_id", "property", "context"}, constLabels,
+	)
+
+	if t, ok := parseTime(report.Timestamp); ok {
+		ch <- prometheus.MustNewConstMetric(timestampDesc, prometheus.GaugeValue, t)
+	}
+
+	type series struct {
+		value     float64
+		timestamp float64
+	}
+
+	// reports appending to their values hold several readings per metric,
+	// only the most recent is exported
+	latest := make(map[[3]string]series)
+	for _, v := range report.MetricValues {
+		value, err := strconv.ParseFloat(v.MetricValue, 64)
+		if err != nil {
+			continue
+		}
+
+		key := [3]string{v.MetricID, v.MetricProperty, v.Oem.Dell.ContextID}
+		timestamp, _ := parseTime(v.Timestamp)
+		if s, ok := latest[key]; ok && s.timestamp > timestamp {
+			continue
+		}
+		latest[key] = series{value, timestamp}
+	}
+
+	for key, s := range latest {
+		m := prometheus.MustNewConstMetric(valueDesc, prometheus.GaugeValue, s.value, key[0], key[1], key[2])
+		if c.timestamps && s.timestamp != 0 {
+			m = prometheus.NewMetricWithTimestamp(time.Unix(0, int64(s.timestamp*1e9)), m)
+		}
+		ch <- m
+	}
+}
@@ -12,6 +12,12 @@ type EndpointConfig struct {
 	Username string
 	Password string
 	Insecure bool
+	// MetricReports lists the TelemetryService metric reports, or the
+	// definitions generating them, to collect
+	MetricReports []string `yaml:"metric_reports"`
+	// MetricReportTimestamps exports metric report values with the time the
+	// BMC took them, rather than at scrape time
+	MetricReportTimestamps bool `yaml:"metric_report_timestamps"`
 }
 
 type Config map[Endpoint]EndpointConfig
@@ -41,3 +47,21 @@ func LoadConfig(path string) (*Config, error) {
 
 	return c, nil
 }
+
+// BIOSBaseline holds the expected BIOS attribute values, keyed by system model
+// and attribute name.
+type BIOSBaseline map[string]map[string]string
+
+func LoadBIOSBaseline(path string) (BIOSBaseline, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading BIOS baseline file: %s", err)
+	}
+
+	baseline := BIOSBaseline{}
+	if err := yaml.Unmarshal(b, &baseline); err != nil {
+		return nil, fmt.Errorf("error unmarshalling BIOS baseline file: %s", err)
+	}
+
+	return baseline, nil
+}
@@ -13,7 +13,7 @@ import (
 	"os"
 )
 
-func handlerFunc(w http.ResponseWriter, r *http.Request, c *config.Config) {
+func handlerFunc(w http.ResponseWriter, r *http.Request, c *config.Config, state *collector.State, baseline config.BIOSBaseline) {
 	params := r.URL.Query()
 	target := params.Get("target")
 	if target == "" {
@@ -43,7 +43,7 @@ func handlerFunc(w http.ResponseWriter, r *http.Request, c *config.Config) {
 		Username: cfg.Username,
 		Password: cfg.Password,
 		Insecure: cfg.Insecure,
-	})
+	}, state, baseline, cfg.MetricReports, cfg.MetricReportTimestamps)
 
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(rc)
@@ -56,6 +56,8 @@ func main() {
 	var (
 		listenAddress = flag.String("listen-address", "0.0.0.0:10015", "address for Prometheus requests")
 		configPath    = flag.String("config-path", "./config.yml", "path to config file")
+		statePath     = flag.String("state-path", "", "path to file persisting state between scrapes (optional)")
+		biosPath      = flag.String("bios-baseline-path", "", "path to BIOS attribute baseline file (optional)")
 	)
 	flag.Parse()
 
@@ -67,11 +69,23 @@ func main() {
 		log.Fatal(err)
 	}
 
+	state, err := collector.NewState(*statePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var baseline config.BIOSBaseline
+	if *biosPath != "" {
+		if baseline, err = config.LoadBIOSBaseline(*biosPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	log.Printf("starting redfish_exporter on %s", *listenAddress)
 
 	http.Handle("/metrics", promhttp.Handler())
 	http.HandleFunc("/redfish", func(w http.ResponseWriter, r *http.Request) {
-		handlerFunc(w, r, cfg)
+		handlerFunc(w, r, cfg, state, baseline)
 	})
 
 	if err := http.ListenAndServe(*listenAddress, nil); err != nil {